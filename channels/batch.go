@@ -0,0 +1,52 @@
+package channels
+
+import "time"
+
+//Batch groups the elements of in into slices of up to size elements. A batch is emitted once it is full, or once
+//maxWait has passed since its first element arrived, whichever comes first. When in is closed, the remaining partial
+//batch is flushed and the output channel is closed
+func Batch[T any](in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	if size < 1 {
+		size = 1
+	}
+	output := make(chan []T)
+	go func() {
+		defer close(output)
+		var batch []T
+		//A nil channel blocks forever, so the timeout case is disabled while there is no pending batch
+		var timeout <-chan time.Time
+		var timer *time.Timer
+		flush := func() {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			if len(batch) > 0 {
+				output <- batch
+				batch = nil
+			}
+		}
+		for {
+			select {
+			case value, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				if len(batch) == 0 {
+					timer = time.NewTimer(maxWait) //The wait starts with the first element of a batch
+					timeout = timer.C
+				}
+				batch = append(batch, value)
+				if len(batch) >= size {
+					flush()
+				}
+			case <-timeout:
+				timer = nil //Already fired, no need to stop it
+				timeout = nil
+				flush()
+			}
+		}
+	}()
+	return output
+}
@@ -0,0 +1,42 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestBatchBySize(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		for i := 0; i < 7; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	var batches [][]int
+	for batch := range channels.Batch[int](in, 3, time.Minute) {
+		batches = append(batches, batch)
+	}
+	//The last partial batch is flushed when the input is closed
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}, {6}}, batches)
+}
+
+func TestBatchByTime(t *testing.T) {
+	in := make(chan int)
+	out := channels.Batch[int](in, 100, 10*time.Millisecond)
+
+	in <- 1
+	in <- 2
+	select {
+	case batch := <-out:
+		assert.Equal(t, []int{1, 2}, batch) //Flushed after maxWait although the batch is not full
+	case <-time.After(time.Second):
+		t.Fatal("batch was not flushed in time")
+	}
+	close(in)
+	_, ok := <-out
+	assert.False(t, ok)
+}
@@ -0,0 +1,33 @@
+package channels
+
+import "context"
+
+//Bridge flattens a stream of channels into a single channel. The inner channels are consumed one after another,
+//in the order they arrive on chanStream. The output is closed when chanStream is closed or ctx is cancelled
+func Bridge[T any](ctx context.Context, chanStream <-chan <-chan T) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		for {
+			var stream <-chan T
+			select {
+			case next, ok := <-chanStream:
+				if !ok {
+					return
+				}
+				stream = next
+			case <-ctx.Done():
+				return
+			}
+			//orDone stops reading an idle inner stream on cancellation
+			for value := range orDone(ctx, stream) {
+				select {
+				case output <- value:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return output
+}
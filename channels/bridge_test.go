@@ -0,0 +1,44 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestBridge(t *testing.T) {
+	chanStream := make(chan (<-chan int))
+	go func() {
+		defer close(chanStream)
+		for i := 0; i < 3; i++ {
+			stream := make(chan int, 2)
+			stream <- i * 10
+			stream <- i*10 + 1
+			close(stream)
+			chanStream <- stream
+		}
+	}()
+
+	var result []int
+	for v := range channels.Bridge[int](context.Background(), chanStream) {
+		result = append(result, v)
+	}
+	assert.Equal(t, []int{0, 1, 10, 11, 20, 21}, result)
+}
+
+func TestBridgeCancelIdleStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	chanStream := make(chan (<-chan int), 1)
+	chanStream <- make(chan int) //Never yields a value
+	output := channels.Bridge[int](ctx, chanStream)
+
+	cancel()
+	select {
+	case _, open := <-output:
+		assert.False(t, open)
+	case <-time.After(time.Second):
+		t.Fatal("output is still open after cancel")
+	}
+}
@@ -0,0 +1,131 @@
+package channels
+
+import "sync"
+
+//SlowConsumerPolicy defines what Publish does when a subscriber's buffer is full
+type SlowConsumerPolicy int
+
+const (
+	//Block waits until the subscriber received the message. A slow subscriber slows down all publishers
+	Block SlowConsumerPolicy = iota
+	//Drop discards the message for a subscriber that is not ready to receive it
+	Drop
+)
+
+type broadcasterConfig struct {
+	buffer int
+	policy SlowConsumerPolicy
+}
+
+type BroadcasterOption func(*broadcasterConfig)
+
+//WithSubscriberBuffer sets the channel buffer size of every subscriber
+func WithSubscriberBuffer(size int) BroadcasterOption {
+	return func(c *broadcasterConfig) {
+		c.buffer = size
+	}
+}
+
+//WithSlowConsumerPolicy sets the behavior for subscribers that do not keep up with the publishers
+func WithSlowConsumerPolicy(policy SlowConsumerPolicy) BroadcasterOption {
+	return func(c *broadcasterConfig) {
+		c.policy = policy
+	}
+}
+
+type subscriber[T any] struct {
+	c    chan T
+	done chan struct{}
+	once sync.Once
+}
+
+//Broadcaster delivers every published message to all of its subscribers, unlike a plain channel where each message
+//is received by one consumer only
+type Broadcaster[T any] struct {
+	mu          sync.RWMutex
+	config      broadcasterConfig
+	subscribers map[*subscriber[T]]struct{}
+	closed      bool
+	done        chan struct{} //Closed by Close without the lock, to release a blocked Publish
+	closeOnce   sync.Once
+}
+
+func NewBroadcaster[T any](opts ...BroadcasterOption) *Broadcaster[T] {
+	b := &Broadcaster[T]{
+		config: broadcasterConfig{
+			buffer: 0,
+			policy: Block,
+		},
+		subscribers: map[*subscriber[T]]struct{}{},
+		done:        make(chan struct{}),
+	}
+	for idx := range opts {
+		opts[idx](&b.config)
+	}
+	return b
+}
+
+//Subscribe registers a new subscriber. It returns the channel to receive messages from and a function to unsubscribe,
+//which closes the channel. Subscribing to a closed Broadcaster returns an already closed channel
+func (b *Broadcaster[T]) Subscribe() (<-chan T, func()) {
+	sub := &subscriber[T]{
+		c:    make(chan T, b.config.buffer),
+		done: make(chan struct{}),
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(sub.c)
+		return sub.c, func() {}
+	}
+	b.subscribers[sub] = struct{}{}
+	return sub.c, func() { b.unsubscribe(sub) }
+}
+
+func (b *Broadcaster[T]) unsubscribe(sub *subscriber[T]) {
+	//Signal first, so a Publish blocked on this subscriber returns and releases the read lock
+	sub.once.Do(func() { close(sub.done) })
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.c)
+	}
+}
+
+//Publish sends message to all current subscribers, honoring the configured SlowConsumerPolicy
+func (b *Broadcaster[T]) Publish(message T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subscribers {
+		if b.config.policy == Drop {
+			select {
+			case sub.c <- message:
+			default:
+			}
+			continue
+		}
+		select {
+		case sub.c <- message:
+		case <-sub.done:
+		case <-b.done:
+			return
+		}
+	}
+}
+
+//Close unsubscribes and closes all subscriber channels. Publishing after Close is a no-op
+func (b *Broadcaster[T]) Close() {
+	//Signal without the lock first: a Publish blocked on a slow subscriber holds the read lock, and a pending
+	//Subscribe or unsubscribe would queue a read lock taken here behind its write lock
+	b.closeOnce.Do(func() { close(b.done) })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		sub.once.Do(func() { close(sub.done) })
+		close(sub.c)
+	}
+	b.subscribers = map[*subscriber[T]]struct{}{}
+	b.closed = true
+}
@@ -0,0 +1,81 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestBroadcaster(t *testing.T) {
+	b := channels.NewBroadcaster[string](channels.WithSubscriberBuffer(2))
+	first, _ := b.Subscribe()
+	second, unsubscribe := b.Subscribe()
+
+	b.Publish("hello")
+	assert.Equal(t, "hello", <-first)
+	assert.Equal(t, "hello", <-second) //Every subscriber receives every message
+
+	unsubscribe()
+	_, ok := <-second
+	assert.False(t, ok)
+
+	b.Publish("world")
+	assert.Equal(t, "world", <-first)
+
+	b.Close()
+	_, ok = <-first
+	assert.False(t, ok)
+}
+
+func TestBroadcasterDropPolicy(t *testing.T) {
+	b := channels.NewBroadcaster[int](
+		channels.WithSubscriberBuffer(1),
+		channels.WithSlowConsumerPolicy(channels.Drop))
+	defer b.Close()
+	c, _ := b.Subscribe()
+
+	b.Publish(1)
+	b.Publish(2) //Buffer is full, the message is dropped instead of blocking
+	assert.Equal(t, 1, <-c)
+	assert.Len(t, c, 0)
+}
+
+func TestBroadcasterUnsubscribeWhileBlocked(t *testing.T) {
+	b := channels.NewBroadcaster[int]()
+	_, unsubscribe := b.Subscribe()
+
+	done := make(chan struct{})
+	go func() {
+		b.Publish(1) //Blocks, nobody is reading
+		close(done)
+	}()
+	unsubscribe() //Releases the blocked publisher
+	<-done
+}
+
+func TestBroadcasterCloseWhileBlocked(t *testing.T) {
+	b := channels.NewBroadcaster[int]()
+	b.Subscribe()
+
+	published := make(chan struct{})
+	go func() {
+		b.Publish(1) //Blocks holding the read lock, nobody is reading
+		close(published)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	go b.Subscribe() //Waits for the write lock
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		b.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close deadlocked")
+	}
+	<-published
+}
@@ -1,16 +1,104 @@
 package channels
 
-import "math/rand"
+import (
+	"context"
+	"math/rand"
+)
 
-func GenerateRandomNumbers(amount int) chan int {
-	output := make(chan int) //Create the channel
+//Rand is the source of random numbers of the generators. *rand.Rand and the fakes in package mocking implement it
+type Rand interface {
+	Int() int
+	Intn(n int) int
+}
+
+type generatorConfig struct {
+	buffer   int
+	random   Rand
+	min, max int
+}
+
+type GeneratorOption func(*generatorConfig)
+
+//WithBuffer sets the buffer size of the generated channel
+func WithBuffer(size int) GeneratorOption {
+	return func(c *generatorConfig) {
+		c.buffer = size
+	}
+}
+
+//WithSeed makes the generator produce a deterministic sequence of numbers, which makes tests reproducible
+func WithSeed(seed int64) GeneratorOption {
+	return WithSource(rand.NewSource(seed))
+}
+
+//WithSource sets the random source the numbers are generated from
+func WithSource(source rand.Source) GeneratorOption {
+	return WithRand(rand.New(source))
+}
+
+//WithRand sets the random number generator. Tests can inject a fixed sequence to assert on exact values
+func WithRand(random Rand) GeneratorOption {
+	return func(c *generatorConfig) {
+		c.random = random
+	}
+}
+
+//WithRange limits the generated numbers to the half open interval [min, max)
+func WithRange(min, max int) GeneratorOption {
+	return func(c *generatorConfig) {
+		c.min, c.max = min, max
+	}
+}
+
+func newGeneratorConfig(opts []GeneratorOption) *generatorConfig {
+	config := &generatorConfig{}
+	//Apply all options
+	for idx := range opts {
+		opts[idx](config)
+	}
+	return config
+}
+
+//number returns the next random number. Without a configured source it uses the global, concurrency safe source
+func (c *generatorConfig) number() int {
+	intn, next := rand.Intn, rand.Int
+	if c.random != nil {
+		intn, next = c.random.Intn, c.random.Int
+	}
+	if c.max > c.min {
+		return c.min + intn(c.max-c.min)
+	}
+	return next()
+}
+
+func GenerateRandomNumbers(amount int, opts ...GeneratorOption) chan int {
+	config := newGeneratorConfig(opts)
+	output := make(chan int, config.buffer) //Create the channel
 	//Populate the channel in a go routine, this happens async, so the returned channel is ready to be consumed elsewhere while it is not populated
 	go func() {
 		for i := 0; i < amount; i++ {
-			output <- rand.Int()
+			output <- config.number()
 		}
 		//Once we are done populating the channel, we close it, this will cause consumer loops to exit gracefully
 		close(output)
 	}()
 	return output
 }
+
+//GenerateRandomNumbersCtx works like GenerateRandomNumbers, but stops producing when ctx is cancelled.
+//This way the producer routine does not leak if the consumer stops reading early
+func GenerateRandomNumbersCtx(ctx context.Context, amount int, opts ...GeneratorOption) <-chan int {
+	config := newGeneratorConfig(opts)
+	output := make(chan int, config.buffer)
+	go func() {
+		defer close(output)
+		for i := 0; i < amount; i++ {
+			select {
+			case output <- config.number():
+			case <-ctx.Done():
+				return //Nobody is listening anymore, exit instead of blocking forever
+			}
+		}
+	}()
+	return output
+}
@@ -3,8 +3,10 @@ package channels_test
 import (
 	"context"
 	"fmt"
+	"github.com/stretchr/testify/assert"
 	"math/rand"
 	"minimalgo/channels"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -183,3 +185,50 @@ func TestOptionalWrite(t *testing.T) {
 		t.Fatal("should not have been executed")
 	}
 }
+
+func TestRoutineWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := channels.GenerateRandomNumbersCtx(ctx, 1000)
+	received := 0
+	<-c
+	<-c
+	cancel() //Stop reading early, the producer routine exits and closes the channel
+	drained := make(chan struct{})
+	go func() {
+		for range c {
+			received++
+		}
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+	//select picks randomly among ready cases, so a few values may still pass after cancel, but not all 1000
+	assert.Less(t, received, 100)
+}
+
+func TestRoutineWithOptions(t *testing.T) {
+	collect := func(c chan int) []int {
+		var numbers []int
+		for n := range c {
+			numbers = append(numbers, n)
+		}
+		return numbers
+	}
+	first := collect(channels.GenerateRandomNumbers(10, channels.WithSeed(42), channels.WithRange(0, 100)))
+	second := collect(channels.GenerateRandomNumbers(10, channels.WithSeed(42), channels.WithRange(0, 100)))
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("same seed should produce the same numbers: %v != %v", first, second)
+	}
+	for _, n := range first {
+		if n < 0 || n >= 100 {
+			t.Fatalf("%d out of range", n)
+		}
+	}
+
+	//The producer fills the buffer without waiting for a consumer
+	buffered := channels.GenerateRandomNumbers(5, channels.WithBuffer(5))
+	assert.Eventually(t, func() bool { return len(buffered) == 5 }, time.Second, time.Millisecond)
+}
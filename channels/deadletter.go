@@ -0,0 +1,38 @@
+package channels
+
+import "context"
+
+//DeadLetter is an item whose processing failed, along with the error
+type DeadLetter[T any] struct {
+	Item T
+	Err  error
+}
+
+//WithDeadLetter applies fn to every item of in. Successful results are sent to the first channel, items fn failed on
+//are routed to the dead-letter channel instead of being dropped. Both channels must be consumed, otherwise the stage
+//blocks. Both are closed when in is closed or ctx is cancelled
+func WithDeadLetter[T, R any](ctx context.Context, in <-chan T, fn func(T) (R, error)) (<-chan R, <-chan DeadLetter[T]) {
+	output := make(chan R)
+	deadLetters := make(chan DeadLetter[T])
+	go func() {
+		defer close(output)
+		defer close(deadLetters)
+		for item := range orDone(ctx, in) {
+			result, err := fn(item)
+			if err != nil {
+				select {
+				case deadLetters <- DeadLetter[T]{Item: item, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+			select {
+			case output <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return output, deadLetters
+}
@@ -0,0 +1,39 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestWithDeadLetter(t *testing.T) {
+	in := make(chan string, 4)
+	for _, s := range []string{"1", "two", "3", "four"} {
+		in <- s
+	}
+	close(in)
+
+	results, deadLetters := channels.WithDeadLetter(context.Background(), in, strconv.Atoi)
+
+	var failed []string
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for dl := range deadLetters {
+			assert.Error(t, dl.Err)
+			failed = append(failed, dl.Item) //The original item is preserved for later inspection or retry
+		}
+	}()
+	var parsed []int
+	for r := range results {
+		parsed = append(parsed, r)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int{1, 3}, parsed)
+	assert.Equal(t, []string{"two", "four"}, failed)
+}
@@ -0,0 +1,29 @@
+package channels
+
+//Drain consumes and discards all values of c until it is closed. Use it to unblock a producer whose results are no
+//longer needed. It blocks until c is closed, so run it in its own routine if you don't own the producer
+func Drain[T any](c <-chan T) int {
+	count := 0
+	for range c {
+		count++
+	}
+	return count
+}
+
+//CloseAndDrain closes c and discards the values still buffered in it. It returns the number of discarded values
+func CloseAndDrain[T any](c chan T) int {
+	SafeClose(c)
+	return Drain[T](c)
+}
+
+//SafeClose closes c and returns true, or returns false if c was already closed instead of panicking.
+//Prefer a design where exactly one owner closes the channel, this is only a safety net
+func SafeClose[T any](c chan T) (closed bool) {
+	defer func() {
+		if recover() != nil {
+			closed = false //close of closed channel
+		}
+	}()
+	close(c)
+	return true
+}
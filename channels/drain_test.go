@@ -0,0 +1,26 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+)
+
+func TestDrain(t *testing.T) {
+	assert.Equal(t, 10, channels.Drain[int](channels.GenerateRandomNumbers(10)))
+}
+
+func TestCloseAndDrain(t *testing.T) {
+	c := make(chan int, 5)
+	c <- 1
+	c <- 2
+	assert.Equal(t, 2, channels.CloseAndDrain(c))
+	_, ok := <-c
+	assert.False(t, ok)
+}
+
+func TestSafeClose(t *testing.T) {
+	c := make(chan int)
+	assert.True(t, channels.SafeClose(c))
+	assert.False(t, channels.SafeClose(c)) //A second close would panic without SafeClose
+}
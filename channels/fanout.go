@@ -0,0 +1,104 @@
+package channels
+
+import (
+	"context"
+	"sync"
+)
+
+//FanOut distributes the elements of in across the given number of worker routines, each applying fn.
+//Results are merged into the returned channel in the order they complete, so input order is not preserved.
+//The output channel is closed once in is closed and all workers are done, or ctx is cancelled
+func FanOut[T, R any](ctx context.Context, in <-chan T, workers int, fn func(T) R) <-chan R {
+	if workers < 1 {
+		workers = 1
+	}
+	output := make(chan R)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case value, ok := <-in:
+					if !ok {
+						return //Input closed, this worker is done
+					}
+					select {
+					case output <- fn(value):
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(output)
+	}()
+	return output
+}
+
+//FanOutE works like FanOut for functions that can fail. Every outcome is sent as a Result, so errors propagate downstream
+func FanOutE[T, R any](ctx context.Context, in <-chan T, workers int, fn func(T) (R, error)) <-chan Result[R] {
+	return FanOut(ctx, in, workers, func(value T) Result[R] {
+		r, err := fn(value)
+		return Result[R]{Value: r, Err: err}
+	})
+}
+
+type sequenced[T any] struct {
+	seq   int
+	value T
+}
+
+//OrderedFanOut works like FanOut, but emits the results in the order of the input. Every element is tagged with a
+//sequence number, results that complete early are held back until all their predecessors were emitted
+func OrderedFanOut[T, R any](ctx context.Context, in <-chan T, workers int, fn func(T) R) <-chan R {
+	//Tag every input element with its position
+	tagged := make(chan sequenced[T])
+	go func() {
+		defer close(tagged)
+		seq := 0
+		for value := range orDone(ctx, in) {
+			select {
+			case tagged <- sequenced[T]{seq: seq, value: value}:
+				seq++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := FanOut(ctx, tagged, workers, func(s sequenced[T]) sequenced[R] {
+		return sequenced[R]{seq: s.seq, value: fn(s.value)}
+	})
+
+	//Re-establish the input order
+	output := make(chan R)
+	go func() {
+		defer close(output)
+		pending := map[int]R{}
+		next := 0
+		for result := range results {
+			pending[result.seq] = result.value
+			for {
+				value, ok := pending[next]
+				if !ok {
+					break //Wait for the next element in sequence
+				}
+				delete(pending, next)
+				select {
+				case output <- value:
+				case <-ctx.Done():
+					return
+				}
+				next++
+			}
+		}
+	}()
+	return output
+}
@@ -0,0 +1,62 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestFanOut(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		for i := 1; i <= 10; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	var results []int
+	for square := range channels.FanOut(context.Background(), in, 3, func(i int) int { return i * i }) {
+		results = append(results, square)
+	}
+	sort.Ints(results) //Workers finish in arbitrary order
+	assert.Equal(t, []int{1, 4, 9, 16, 25, 36, 49, 64, 81, 100}, results)
+}
+
+func TestFanOutCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int) //Never closed, only cancellation ends the workers
+	out := channels.FanOut(ctx, in, 2, func(i int) int { return i })
+
+	cancel()
+	_, ok := <-out
+	assert.False(t, ok)
+}
+
+func TestOrderedFanOut(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		for i := 0; i < 20; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	var results []int
+	slowForEven := func(i int) int {
+		if i%2 == 0 {
+			time.Sleep(time.Millisecond) //Make even numbers finish late
+		}
+		return i * 10
+	}
+	for r := range channels.OrderedFanOut(context.Background(), in, 4, slowForEven) {
+		results = append(results, r)
+	}
+	assert.Len(t, results, 20)
+	for i, r := range results {
+		assert.Equal(t, i*10, r) //Input order is preserved
+	}
+}
@@ -0,0 +1,49 @@
+package channels
+
+import (
+	"context"
+	"errors"
+)
+
+//ErrNoFunctions is returned by First when called without any function
+var ErrNoFunctions = errors.New("no functions provided")
+
+type firstResult[T any] struct {
+	value T
+	err   error
+}
+
+//First runs all fns concurrently and returns the first successful result. The context passed to the functions is
+//cancelled as soon as a result is available, so the remaining functions can stop early. If all functions fail,
+//the joined errors are returned. This is also known as a hedged request
+func First[T any](ctx context.Context, fns ...func(context.Context) (T, error)) (T, error) {
+	var zero T
+	if len(fns) == 0 {
+		return zero, ErrNoFunctions
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() //Cancels the functions still running once we return
+
+	//Buffered to the number of functions, so the losing routines never block on send and don't leak
+	results := make(chan firstResult[T], len(fns))
+	for _, fn := range fns {
+		go func(fn func(context.Context) (T, error)) {
+			value, err := fn(ctx)
+			results <- firstResult[T]{value: value, err: err}
+		}(fn)
+	}
+
+	var errs []error
+	for range fns {
+		select {
+		case result := <-results:
+			if result.err == nil {
+				return result.value, nil
+			}
+			errs = append(errs, result.err)
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+	return zero, errors.Join(errs...)
+}
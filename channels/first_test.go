@@ -0,0 +1,49 @@
+package channels_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestFirst(t *testing.T) {
+	slowCancelled := make(chan struct{})
+	value, err := channels.First(context.Background(),
+		func(ctx context.Context) (string, error) {
+			select {
+			case <-time.After(time.Minute):
+				return "slow", nil
+			case <-ctx.Done():
+				close(slowCancelled)
+				return "", ctx.Err()
+			}
+		},
+		func(ctx context.Context) (string, error) {
+			return "", errors.New("failed")
+		},
+		func(ctx context.Context) (string, error) {
+			time.Sleep(10 * time.Millisecond)
+			return "fast", nil
+		},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "fast", value)
+	<-slowCancelled //The slow function got cancelled
+}
+
+func TestFirstAllFail(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	_, err := channels.First(context.Background(),
+		func(ctx context.Context) (int, error) { return 0, errA },
+		func(ctx context.Context) (int, error) { return 0, errB },
+	)
+	assert.True(t, errors.Is(err, errA))
+	assert.True(t, errors.Is(err, errB))
+
+	_, err = channels.First[int](context.Background())
+	assert.Equal(t, channels.ErrNoFunctions, err)
+}
@@ -0,0 +1,41 @@
+package channels
+
+import "context"
+
+//Repeat sends the given values over and over again, in order, until ctx is cancelled. Without values the
+//returned channel is closed immediately
+func Repeat[T any](ctx context.Context, values ...T) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		if len(values) == 0 {
+			return
+		}
+		for {
+			for _, value := range values {
+				select {
+				case output <- value:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return output
+}
+
+//RepeatFn calls fn and sends its result, over and over again until ctx is cancelled
+func RepeatFn[T any](ctx context.Context, fn func() T) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		for {
+			select {
+			case output <- fn():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return output
+}
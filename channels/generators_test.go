@@ -0,0 +1,35 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"minimalgo/channels"
+	"testing"
+)
+
+func TestRepeat(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var result []string
+	for v := range channels.Take(ctx, channels.Repeat(ctx, "a", "b"), 5) {
+		result = append(result, v)
+	}
+	assert.Equal(t, []string{"a", "b", "a", "b", "a"}, result)
+
+	_, ok := <-channels.Repeat[int](ctx)
+	assert.False(t, ok)
+}
+
+func TestRepeatFn(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//The random number generator as one pluggable source of a pipeline
+	count := 0
+	for range channels.Take(ctx, channels.RepeatFn(ctx, rand.Int), 10) {
+		count++
+	}
+	assert.Equal(t, 10, count)
+}
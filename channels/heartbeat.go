@@ -0,0 +1,95 @@
+package channels
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+//HeartbeatWorker does its work sending results to out until ctx is cancelled. It must call beat regularly, at least
+//once per heartbeat interval, to signal it is alive. The worker must not close out, this is done by the wrapper
+type HeartbeatWorker[T any] func(ctx context.Context, beat func(), out chan<- T)
+
+//WithHeartbeat runs worker in its own routine and returns its output channel along with a heartbeat channel.
+//A pulse is sent on the heartbeat channel every interval, but only if the worker called beat in the meantime, so a
+//stalled worker stops pulsing. Pulses nobody receives are discarded. Both channels are closed once the worker returns
+func WithHeartbeat[T any](ctx context.Context, interval time.Duration, worker HeartbeatWorker[T]) (<-chan T, <-chan struct{}) {
+	output := make(chan T)
+	heartbeat := make(chan struct{}, 1)
+	var alive atomic.Bool
+	done := make(chan struct{})
+
+	go func() {
+		defer close(output)
+		defer close(done)
+		worker(ctx, func() { alive.Store(true) }, output)
+	}()
+	go func() {
+		defer close(heartbeat)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if !alive.Swap(false) {
+					continue //No sign of life since the last tick
+				}
+				select {
+				case heartbeat <- struct{}{}:
+				default: //Nobody is listening, don't block
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return output, heartbeat
+}
+
+//Supervise runs worker using WithHeartbeat and forwards its output. If no heartbeat is received for timeout, the
+//worker's context is cancelled and a fresh worker is started. The output channel is closed when a worker returns
+//on its own or ctx is cancelled
+func Supervise[T any](ctx context.Context, interval, timeout time.Duration, worker HeartbeatWorker[T]) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		for {
+			workerCtx, cancel := context.WithCancel(ctx)
+			values, heartbeat := WithHeartbeat(workerCtx, interval, worker)
+			restart := superviseOnce(ctx, timeout, values, heartbeat, output)
+			cancel()
+			if !restart {
+				return
+			}
+		}
+	}()
+	return output
+}
+
+//superviseOnce forwards values until the worker is done, returning true if the worker missed its heartbeat
+func superviseOnce[T any](ctx context.Context, timeout time.Duration, values <-chan T, heartbeat <-chan struct{}, output chan<- T) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case value, ok := <-values:
+			if !ok {
+				return false
+			}
+			select {
+			case output <- value:
+			case <-ctx.Done():
+				return false
+			}
+		case <-heartbeat:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(timeout)
+		case <-timer.C:
+			return true //Worker stalled
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHeartbeat(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	worker := func(ctx context.Context, beat func(), out chan<- int) {
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			select {
+			case <-ticker.C:
+				beat()
+			case out <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+	values, heartbeat := channels.WithHeartbeat[int](ctx, 5*time.Millisecond, worker)
+
+	assert.Equal(t, 0, <-values)
+	_, ok := <-heartbeat
+	assert.True(t, ok) //The worker is alive
+
+	cancel()
+	for range values {
+	}
+	for range heartbeat {
+	}
+}
+
+func TestSupervise(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var starts atomic.Int32
+	worker := func(ctx context.Context, beat func(), out chan<- int) {
+		if starts.Add(1) == 1 {
+			<-ctx.Done() //The first worker stalls without beating
+			return
+		}
+		beat()
+		out <- 42
+	}
+	values := channels.Supervise[int](ctx, 5*time.Millisecond, 20*time.Millisecond, worker)
+
+	assert.Equal(t, 42, <-values) //Delivered by the restarted worker
+	_, ok := <-values
+	assert.False(t, ok) //The second worker returned on its own
+	assert.Equal(t, int32(2), starts.Load())
+}
@@ -0,0 +1,92 @@
+package channels
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+//ChanStats is a snapshot of the metrics of an InstrumentedChan
+type ChanStats struct {
+	Name        string
+	Sends       int64
+	Receives    int64
+	SendBlocked time.Duration //Total time senders waited for the channel
+	RecvBlocked time.Duration //Total time receivers waited for the channel
+	Len         int           //Current buffer depth
+	Cap         int
+}
+
+//InstrumentedChan wraps a channel and records metrics about its usage. Send and receive through the wrapper
+//to have the operations counted, direct operations on the underlying channel are only reflected in Len
+type InstrumentedChan[T any] struct {
+	c           chan T
+	name        string
+	sends       atomic.Int64
+	receives    atomic.Int64
+	sendBlocked atomic.Int64
+	recvBlocked atomic.Int64
+}
+
+//Instrument wraps c. The name identifies the channel in the stats and the expvar publication
+func Instrument[T any](c chan T, name string) *InstrumentedChan[T] {
+	return &InstrumentedChan[T]{
+		c:    c,
+		name: name,
+	}
+}
+
+//Send sends value to the channel, blocking like a regular send
+func (i *InstrumentedChan[T]) Send(value T) {
+	select {
+	case i.c <- value: //Fast path, no need to measure time if the send doesn't block
+	default:
+		start := time.Now()
+		i.c <- value
+		i.sendBlocked.Add(int64(time.Since(start)))
+	}
+	i.sends.Add(1)
+}
+
+//Recv receives from the channel, blocking like a regular receive. It returns false if the channel is closed
+func (i *InstrumentedChan[T]) Recv() (T, bool) {
+	var value T
+	var ok bool
+	select {
+	case value, ok = <-i.c:
+	default:
+		start := time.Now()
+		value, ok = <-i.c
+		i.recvBlocked.Add(int64(time.Since(start)))
+	}
+	if ok {
+		i.receives.Add(1)
+	}
+	return value, ok
+}
+
+//Close closes the underlying channel
+func (i *InstrumentedChan[T]) Close() {
+	close(i.c)
+}
+
+//Stats returns a snapshot of the current metrics
+func (i *InstrumentedChan[T]) Stats() ChanStats {
+	return ChanStats{
+		Name:        i.name,
+		Sends:       i.sends.Load(),
+		Receives:    i.receives.Load(),
+		SendBlocked: time.Duration(i.sendBlocked.Load()),
+		RecvBlocked: time.Duration(i.recvBlocked.Load()),
+		Len:         len(i.c),
+		Cap:         cap(i.c),
+	}
+}
+
+//PublishExpvar exposes the stats as expvar under the channel's name, served on /debug/vars.
+//Like expvar.Publish, it panics if the name is already in use
+func (i *InstrumentedChan[T]) PublishExpvar() {
+	expvar.Publish(i.name, expvar.Func(func() any {
+		return i.Stats()
+	}))
+}
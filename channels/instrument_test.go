@@ -0,0 +1,41 @@
+package channels_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestInstrument(t *testing.T) {
+	c := channels.Instrument(make(chan int, 2), "test_chan")
+	c.Send(1)
+	c.Send(2)
+
+	stats := c.Stats()
+	assert.Equal(t, int64(2), stats.Sends)
+	assert.Equal(t, 2, stats.Len)
+	assert.Equal(t, 2, stats.Cap)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.Recv()
+	}()
+	c.Send(3) //Blocks until the routine above makes room
+	assert.GreaterOrEqual(t, c.Stats().SendBlocked, 10*time.Millisecond)
+
+	c.Close()
+	for {
+		if _, ok := c.Recv(); !ok {
+			break
+		}
+	}
+	assert.Equal(t, int64(3), c.Stats().Receives)
+
+	c.PublishExpvar()
+	var published channels.ChanStats
+	assert.Nil(t, json.Unmarshal([]byte(expvar.Get("test_chan").String()), &published))
+	assert.Equal(t, int64(3), published.Sends)
+}
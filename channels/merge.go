@@ -0,0 +1,26 @@
+package channels
+
+import "sync"
+
+//Merge combines multiple producer channels into a single output channel (fan-in).
+//The output channel is closed once all input channels are closed
+func Merge[T any](chans ...<-chan T) <-chan T {
+	output := make(chan T)
+	wg := sync.WaitGroup{}
+	wg.Add(len(chans))
+	for _, c := range chans {
+		//Pass the channel as parameter to avoid the closure pitfall
+		go func(c <-chan T) {
+			defer wg.Done()
+			for value := range c {
+				output <- value
+			}
+		}(c)
+	}
+	//Close the output only once every forwarding routine is done, closing earlier would cause a panic on send
+	go func() {
+		wg.Wait()
+		close(output)
+	}()
+	return output
+}
@@ -0,0 +1,25 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	merged := channels.Merge[int](
+		channels.GenerateRandomNumbers(10),
+		channels.GenerateRandomNumbers(5),
+		channels.GenerateRandomNumbers(0),
+	)
+	count := 0
+	for range merged { //Loop exits once all inputs are closed
+		count++
+	}
+	assert.Equal(t, 15, count)
+}
+
+func TestMergeNoInput(t *testing.T) {
+	_, ok := <-channels.Merge[string]()
+	assert.False(t, ok) //Output is closed right away
+}
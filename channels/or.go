@@ -0,0 +1,36 @@
+package channels
+
+//Or combines multiple done channels into one, which is closed as soon as any of the inputs is closed.
+//It uses the recursive or-channel pattern: every level of recursion waits on a few channels and the done channel of
+//the rest. Without inputs, nil is returned, which blocks forever
+func Or(chans ...<-chan struct{}) <-chan struct{} {
+	switch len(chans) {
+	case 0:
+		return nil
+	case 1:
+		return chans[0]
+	}
+
+	orDone := make(chan struct{})
+	go func() {
+		defer close(orDone)
+		switch len(chans) {
+		case 2:
+			select {
+			case <-chans[0]:
+			case <-chans[1]:
+			}
+		default:
+			//Copy the rest, appending to chans directly could overwrite the caller's backing array
+			rest := append(append([]<-chan struct{}(nil), chans[3:]...), orDone)
+			select {
+			case <-chans[0]:
+			case <-chans[1]:
+			case <-chans[2]:
+			//Pass orDone down, so the routines of the subtree exit when this level is done
+			case <-Or(rest...):
+			}
+		}
+	}()
+	return orDone
+}
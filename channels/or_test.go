@@ -0,0 +1,34 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func after(d time.Duration) <-chan struct{} {
+	c := make(chan struct{})
+	go func() {
+		defer close(c)
+		time.Sleep(d)
+	}()
+	return c
+}
+
+func TestOr(t *testing.T) {
+	start := time.Now()
+	<-channels.Or(
+		after(time.Hour),
+		after(time.Minute),
+		after(10*time.Millisecond),
+		after(time.Hour),
+		after(time.Second),
+	)
+	assert.Less(t, time.Since(start), time.Second) //Closed by the fastest input
+
+	cancel := make(chan struct{})
+	close(cancel)
+	<-channels.Or(cancel) //A single channel is returned as is
+	assert.Nil(t, channels.Or())
+}
@@ -0,0 +1,39 @@
+package channels
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+//PartitionBy distributes the items of in across n output channels by the hash of their key. All items with the same
+//key go to the same channel, so a worker per channel processes them in order. The hash is stable across runs.
+//Items are distributed by a single routine, so a slow partition holds back the others. All outputs are closed when
+//in is closed
+func PartitionBy[T any, K comparable](in <-chan T, n int, keyFn func(T) K) []<-chan T {
+	if n < 1 {
+		n = 1
+	}
+	partitions := make([]chan T, n)
+	outputs := make([]<-chan T, n)
+	for i := range partitions {
+		partitions[i] = make(chan T)
+		outputs[i] = partitions[i]
+	}
+	go func() {
+		defer func() {
+			for _, p := range partitions {
+				close(p)
+			}
+		}()
+		for item := range in {
+			partitions[partition(keyFn(item), n)] <- item
+		}
+	}()
+	return outputs
+}
+
+func partition[K comparable](key K, n int) int {
+	h := fnv.New32a()
+	fmt.Fprint(h, key)
+	return int(h.Sum32() % uint32(n))
+}
@@ -0,0 +1,54 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"sync"
+	"testing"
+)
+
+type order struct {
+	Customer string
+	Seq      int
+}
+
+func TestPartitionBy(t *testing.T) {
+	in := make(chan order)
+	go func() {
+		defer close(in)
+		for seq := 0; seq < 10; seq++ {
+			for _, customer := range []string{"Avid", "Olav", "Jarl Varg"} {
+				in <- order{Customer: customer, Seq: seq}
+			}
+		}
+	}()
+
+	partitions := channels.PartitionBy(in, 2, func(o order) string { return o.Customer })
+	assert.Len(t, partitions, 2)
+
+	mu := sync.Mutex{}
+	seen := map[string][]int{}
+	owner := map[string]int{}
+	wg := sync.WaitGroup{}
+	wg.Add(len(partitions))
+	for i, p := range partitions {
+		go func(i int, p <-chan order) {
+			defer wg.Done()
+			for o := range p {
+				mu.Lock()
+				if prev, ok := owner[o.Customer]; ok {
+					assert.Equal(t, prev, i) //A key always lands on the same worker
+				}
+				owner[o.Customer] = i
+				seen[o.Customer] = append(seen[o.Customer], o.Seq)
+				mu.Unlock()
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	for _, seqs := range seen {
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, seqs) //Per-key order is preserved
+	}
+	assert.Len(t, seen, 3)
+}
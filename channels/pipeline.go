@@ -0,0 +1,96 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+//Pipeline runs a set of connected stages and takes care of their shutdown. Stages are registered with AddSource,
+//AddStage and AddSink, which return the channel connecting a stage to the next one. The pipeline owns these
+//channels: a stage's output channel is closed once the stage returns, and stages must not close them themselves.
+//Stages must select on ctx.Done() when sending, so they stop when the pipeline is cancelled
+type Pipeline struct {
+	stages []func(ctx context.Context, stop func()) error
+}
+
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+//AddSource registers a stage producing values to out
+func AddSource[T any](p *Pipeline, fn func(ctx context.Context, out chan<- T) error) <-chan T {
+	out := make(chan T)
+	p.stages = append(p.stages, func(ctx context.Context, stop func()) error {
+		defer close(out)
+		return fn(ctx, out)
+	})
+	return out
+}
+
+//AddStage registers a stage consuming in and producing values to out
+func AddStage[T, R any](p *Pipeline, in <-chan T, fn func(ctx context.Context, in <-chan T, out chan<- R) error) <-chan R {
+	out := make(chan R)
+	p.stages = append(p.stages, func(ctx context.Context, stop func()) error {
+		//Drain runs last: if the stage returns early, the upstream stage must not block forever on its next send
+		defer Drain(in)
+		defer close(out)
+		err := fn(ctx, in, out)
+		if err != nil {
+			stop() //Cancels the upstream stages before draining, an endless source would never finish otherwise
+		}
+		return err
+	})
+	return out
+}
+
+//AddSink registers a final stage consuming in. Once a sink returns, nobody needs the pipeline's data anymore and
+//all other stages are cancelled
+func AddSink[T any](p *Pipeline, in <-chan T, fn func(ctx context.Context, in <-chan T) error) {
+	p.stages = append(p.stages, func(ctx context.Context, stop func()) error {
+		defer Drain(in)
+		defer stop()
+		return fn(ctx, in)
+	})
+}
+
+//Run starts all stages and blocks until every stage returned. When a stage fails, all other stages are cancelled
+//and the first error is returned. If ctx is cancelled, the context error is returned
+func (p *Pipeline) Run(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	stopped := false //Set when the pipeline was cancelled internally, either by a failure or a finished sink
+	stop := func() {
+		mu.Lock()
+		stopped = true
+		mu.Unlock()
+		cancel()
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(p.stages))
+	for _, stage := range p.stages {
+		go func(stage func(context.Context, func()) error) {
+			defer wg.Done()
+			err := stage(ctx, stop)
+			if err == nil {
+				return
+			}
+			mu.Lock()
+			//Cancellation errors caused by our own stop are a consequence, not the cause
+			if firstErr == nil && !(stopped && errors.Is(err, context.Canceled)) {
+				firstErr = err
+			}
+			mu.Unlock()
+			stop()
+		}(stage)
+	}
+	wg.Wait()
+	if firstErr == nil {
+		return parent.Err()
+	}
+	return firstErr
+}
@@ -0,0 +1,93 @@
+package channels_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"strconv"
+	"testing"
+)
+
+//source produces numbers until the pipeline is cancelled
+func source(ctx context.Context, out chan<- int) error {
+	for i := 0; ; i++ {
+		select {
+		case out <- i:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	p := channels.NewPipeline()
+	nums := channels.AddSource(p, source)
+	strs := channels.AddStage(p, nums, func(ctx context.Context, in <-chan int, out chan<- string) error {
+		for i := range in {
+			select {
+			case out <- strconv.Itoa(i):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+	var result []string
+	channels.AddSink(p, strs, func(ctx context.Context, in <-chan string) error {
+		for s := range in {
+			result = append(result, s)
+			if len(result) == 3 {
+				return nil //Stops the infinite source
+			}
+		}
+		return nil
+	})
+
+	assert.Nil(t, p.Run(context.Background()))
+	assert.Equal(t, []string{"0", "1", "2"}, result)
+}
+
+func TestPipelineFailure(t *testing.T) {
+	failed := errors.New("stage failed")
+	p := channels.NewPipeline()
+	nums := channels.AddSource(p, source)
+	failing := channels.AddStage(p, nums, func(ctx context.Context, in <-chan int, out chan<- int) error {
+		for i := range in {
+			if i == 5 {
+				return failed
+			}
+		}
+		return nil
+	})
+	channels.AddSink(p, failing, func(ctx context.Context, in <-chan int) error {
+		channels.Drain(in)
+		return nil
+	})
+
+	//All stages are shut down and the cause is returned
+	assert.Equal(t, failed, p.Run(context.Background()))
+}
+
+func TestPipelineFailureWithoutSink(t *testing.T) {
+	failed := errors.New("stage failed")
+	p := channels.NewPipeline()
+	channels.AddStage(p, channels.AddSource(p, source), func(ctx context.Context, in <-chan int, out chan<- int) error {
+		<-in
+		return failed
+	})
+
+	//The endless source is cancelled although no sink stops the pipeline
+	assert.Equal(t, failed, p.Run(context.Background()))
+}
+
+func TestPipelineCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := channels.NewPipeline()
+	channels.AddSink(p, channels.AddSource(p, source), func(ctx context.Context, in <-chan int) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.Equal(t, context.Canceled, p.Run(ctx))
+}
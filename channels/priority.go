@@ -0,0 +1,126 @@
+package channels
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+type prioritySource[T any] struct {
+	c        <-chan T
+	priority int
+}
+
+//PrioritySelect reads from multiple sources and forwards their values to a single output channel. Unlike a plain
+//select, which picks randomly among ready cases, a higher priority source is always preferred when several are ready
+type PrioritySelect[T any] struct {
+	mu      sync.Mutex
+	sources []prioritySource[T] //Sorted by descending priority
+	added   chan struct{}
+	output  chan T
+}
+
+//NewPrioritySelect creates a PrioritySelect. The output channel is closed when ctx is cancelled
+func NewPrioritySelect[T any](ctx context.Context) *PrioritySelect[T] {
+	p := &PrioritySelect[T]{
+		added:  make(chan struct{}, 1),
+		output: make(chan T),
+	}
+	go p.run(ctx)
+	return p
+}
+
+//AddSource registers c with the given priority, higher values are preferred. Closed sources are removed automatically
+func (p *PrioritySelect[T]) AddSource(c <-chan T, priority int) {
+	p.mu.Lock()
+	p.sources = append(p.sources, prioritySource[T]{c: c, priority: priority})
+	//Stable sort keeps registration order for sources of equal priority
+	sort.SliceStable(p.sources, func(i, j int) bool {
+		return p.sources[i].priority > p.sources[j].priority
+	})
+	p.mu.Unlock()
+	//Wake up the run loop, so it includes the new source. The buffer of 1 is enough as one wakeup covers all additions
+	select {
+	case p.added <- struct{}{}:
+	default:
+	}
+}
+
+//Out returns the merged output channel
+func (p *PrioritySelect[T]) Out() <-chan T {
+	return p.output
+}
+
+func (p *PrioritySelect[T]) snapshot() []prioritySource[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]prioritySource[T](nil), p.sources...)
+}
+
+func (p *PrioritySelect[T]) remove(c <-chan T) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.sources {
+		if p.sources[i].c == c {
+			p.sources = append(p.sources[:i], p.sources[i+1:]...)
+			return
+		}
+	}
+}
+
+func (p *PrioritySelect[T]) run(ctx context.Context) {
+	defer close(p.output)
+	for {
+		value, ok := p.next(ctx)
+		if !ok {
+			return
+		}
+		select {
+		case p.output <- value:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+//next returns the next value, preferring high priority sources. It returns false once ctx is cancelled
+func (p *PrioritySelect[T]) next(ctx context.Context) (T, bool) {
+	for {
+		sources := p.snapshot()
+		//First pass: poll sources in priority order without blocking
+		for _, source := range sources {
+			select {
+			case value, ok := <-source.c:
+				if !ok {
+					p.remove(source.c)
+					continue
+				}
+				return value, true
+			default:
+			}
+		}
+		//Nothing ready: block until any source, a new source or cancellation. The number of sources is only known at
+		//runtime, so reflect.Select is needed instead of a select statement
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(p.added)},
+		}
+		for _, source := range sources {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(source.c)})
+		}
+		chosen, value, ok := reflect.Select(cases)
+		switch {
+		case chosen == 0:
+			var zero T
+			return zero, false
+		case chosen == 1:
+			continue
+		case !ok:
+			p.remove(sources[chosen-2].c)
+		default:
+			v, _ := value.Interface().(T) //A nil interface value converts to the zero T instead of panicking
+			return v, true
+		}
+	}
+}
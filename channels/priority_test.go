@@ -0,0 +1,56 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestPrioritySelect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	low := make(chan string, 3)
+	high := make(chan string, 3)
+	for i := 0; i < 3; i++ {
+		low <- "low"
+		high <- "high"
+	}
+
+	p := channels.NewPrioritySelect[string](ctx)
+	p.AddSource(high, 10)
+	p.AddSource(low, 1)
+
+	//Both sources are ready, the high priority source is drained first
+	var result []string
+	for i := 0; i < 6; i++ {
+		result = append(result, <-p.Out())
+	}
+	assert.Equal(t, []string{"high", "high", "high", "low", "low", "low"}, result)
+
+	//Sources added later are picked up as well
+	late := make(chan string)
+	p.AddSource(late, 0)
+	go func() { late <- "late" }()
+	assert.Equal(t, "late", <-p.Out())
+
+	cancel()
+	for range p.Out() {
+	}
+}
+
+func TestPrioritySelectNilInterface(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error)
+	p := channels.NewPrioritySelect[error](ctx)
+	p.AddSource(errs, 0)
+	go func() {
+		time.Sleep(10 * time.Millisecond) //Let the run loop block, so the value is received through reflection
+		errs <- nil
+	}()
+	assert.Nil(t, <-p.Out())
+}
@@ -0,0 +1,66 @@
+package channels
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+)
+
+//Lines streams the lines of r, without line endings. Read errors are delivered on the error channel, which receives
+//at most one error and is closed together with the line channel once r is exhausted, fails or ctx is cancelled
+func Lines(ctx context.Context, r io.Reader) (<-chan string, <-chan error) {
+	output := make(chan string)
+	errc := make(chan error, 1) //Buffered, so reporting the error never blocks
+	go func() {
+		defer close(errc)
+		defer close(output)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case output <- scanner.Text():
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			errc <- err
+		}
+	}()
+	return output, errc
+}
+
+//Chunks streams the content of r in chunks of size bytes, the last chunk may be shorter. Errors are reported like in Lines
+func Chunks(ctx context.Context, r io.Reader, size int) (<-chan []byte, <-chan error) {
+	if size < 1 {
+		size = 1
+	}
+	output := make(chan []byte)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(output)
+		for {
+			//Allocate a new buffer per chunk, the consumer owns the chunks it received
+			chunk := make([]byte, size)
+			n, err := io.ReadFull(r, chunk)
+			if n > 0 {
+				select {
+				case output <- chunk[:n]:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return //End of input, not an error
+			}
+			if err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+	return output, errc
+}
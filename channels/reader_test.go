@@ -0,0 +1,50 @@
+package channels_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	lines, errc := channels.Lines(context.Background(), strings.NewReader("first\nsecond\r\nthird"))
+	var result []string
+	for line := range lines {
+		result = append(result, line)
+	}
+	assert.Equal(t, []string{"first", "second", "third"}, result)
+	assert.Nil(t, <-errc)
+}
+
+func TestChunks(t *testing.T) {
+	chunks, errc := channels.Chunks(context.Background(), strings.NewReader("abcdefg"), 3)
+	var result []string
+	for chunk := range chunks {
+		result = append(result, string(chunk))
+	}
+	assert.Equal(t, []string{"abc", "def", "g"}, result)
+	assert.Nil(t, <-errc)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("disk on fire")
+}
+
+func TestReaderErrors(t *testing.T) {
+	chunks, errc := channels.Chunks(context.Background(), failingReader{}, 3)
+	assert.Equal(t, 0, channels.Drain(chunks))
+	assert.EqualError(t, <-errc, "disk on fire")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	lines, errc := channels.Lines(ctx, strings.NewReader("a\nb\n"))
+	//Nobody receives from lines, so the cancellation is noticed before the first line is sent
+	assert.Equal(t, context.Canceled, <-errc)
+	_, ok := <-lines
+	assert.False(t, ok)
+}
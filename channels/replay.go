@@ -0,0 +1,86 @@
+package channels
+
+import "sync"
+
+//Replayer broadcasts the values of a channel to all subscribers. New subscribers first receive the most recent values
+//before getting live values, which is useful for status streams where late consumers need recent history
+type Replayer[T any] struct {
+	mu          sync.Mutex
+	size        int
+	history     []T
+	subscribers map[*subscriber[T]]struct{}
+	closed      bool
+}
+
+//Replay starts consuming in and keeps the last buffer values for replay. When in is closed, all subscriber channels
+//are closed. Subscribers still receive the history after that
+func Replay[T any](in <-chan T, buffer int) *Replayer[T] {
+	if buffer < 0 {
+		buffer = 0
+	}
+	r := &Replayer[T]{
+		size:        buffer,
+		subscribers: map[*subscriber[T]]struct{}{},
+	}
+	go r.run(in)
+	return r
+}
+
+func (r *Replayer[T]) run(in <-chan T) {
+	for value := range in {
+		r.mu.Lock()
+		if r.size > 0 {
+			if len(r.history) == r.size {
+				r.history = r.history[1:]
+			}
+			r.history = append(r.history, value)
+		}
+		//Delivering under the lock guarantees a new subscriber sees every value exactly once, either replayed or live
+		for sub := range r.subscribers {
+			select {
+			case sub.c <- value:
+			case <-sub.done:
+			}
+		}
+		r.mu.Unlock()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for sub := range r.subscribers {
+		close(sub.c)
+	}
+	r.subscribers = nil
+	r.closed = true
+}
+
+//Subscribe returns a channel that receives the buffered history followed by live values, and a function to
+//unsubscribe which closes the channel
+func (r *Replayer[T]) Subscribe() (<-chan T, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	//The channel buffer fits the whole history, so replaying never blocks
+	sub := &subscriber[T]{
+		c:    make(chan T, r.size),
+		done: make(chan struct{}),
+	}
+	for _, value := range r.history {
+		sub.c <- value
+	}
+	if r.closed {
+		close(sub.c)
+		return sub.c, func() {}
+	}
+	r.subscribers[sub] = struct{}{}
+	return sub.c, func() { r.unsubscribe(sub) }
+}
+
+func (r *Replayer[T]) unsubscribe(sub *subscriber[T]) {
+	//Signal first, so a delivery blocked on this subscriber returns and releases the lock
+	sub.once.Do(func() { close(sub.done) })
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subscribers[sub]; ok {
+		delete(r.subscribers, sub)
+		close(sub.c)
+	}
+}
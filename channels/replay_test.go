@@ -0,0 +1,34 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	in := make(chan string)
+	r := channels.Replay[string](in, 2)
+
+	early, _ := r.Subscribe()
+	for _, status := range []string{"starting", "loading", "ready"} {
+		in <- status
+		assert.Equal(t, status, <-early)
+	}
+
+	//A late subscriber gets the last two values right away, then live values
+	late, unsubscribe := r.Subscribe()
+	assert.Equal(t, "loading", <-late)
+	assert.Equal(t, "ready", <-late)
+	in <- "busy"
+	assert.Equal(t, "busy", <-early)
+	assert.Equal(t, "busy", <-late)
+
+	unsubscribe()
+	_, ok := <-late
+	assert.False(t, ok)
+
+	close(in)
+	_, ok = <-early
+	assert.False(t, ok)
+}
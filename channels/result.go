@@ -0,0 +1,8 @@
+package channels
+
+//Result carries either the value or the error of an operation through a channel. Errors travel downstream with the
+//data instead of being dropped or requiring a separate error channel
+type Result[T any] struct {
+	Value T
+	Err   error
+}
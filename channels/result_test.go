@@ -0,0 +1,53 @@
+package channels_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"strconv"
+	"testing"
+)
+
+func TestMapE(t *testing.T) {
+	in := make(chan string, 3)
+	in <- "1"
+	in <- "x"
+	in <- "3"
+	close(in)
+
+	var values []int
+	var errs []error
+	for result := range channels.MapE(context.Background(), in, strconv.Atoi) {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+			continue
+		}
+		values = append(values, result.Value)
+	}
+	assert.Equal(t, []int{1, 3}, values)
+	assert.Len(t, errs, 1)
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(errs[0], &numErr))
+}
+
+func TestFanOutE(t *testing.T) {
+	failed := errors.New("odd")
+	failOdd := func(i int) (int, error) {
+		if i%2 == 1 {
+			return 0, failed
+		}
+		return i, nil
+	}
+
+	sum, errCount := 0, 0
+	for result := range channels.FanOutE(context.Background(), numbers(10), 3, failOdd) {
+		if errors.Is(result.Err, failed) {
+			errCount++
+			continue
+		}
+		sum += result.Value
+	}
+	assert.Equal(t, 5, errCount)
+	assert.Equal(t, 0+2+4+6+8, sum)
+}
@@ -0,0 +1,62 @@
+package channels
+
+import "sync"
+
+//RingChan is a bounded channel whose sends never block. When the buffer is full, the oldest element is evicted to make
+//room for the new one. Compare with the select-default optional write, which discards the newest element instead
+type RingChan[T any] struct {
+	mu     sync.Mutex
+	c      chan T
+	closed bool
+}
+
+func NewRingChan[T any](size int) *RingChan[T] {
+	if size < 1 {
+		size = 1
+	}
+	return &RingChan[T]{
+		c: make(chan T, size),
+	}
+}
+
+//Send adds value to the buffer, evicting the oldest element if the buffer is full. Send on a closed RingChan is a no-op
+func (r *RingChan[T]) Send(value T) {
+	//Senders are serialized, otherwise two senders could keep evicting each other's elements
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	for {
+		select {
+		case r.c <- value:
+			return
+		default:
+			//Buffer full, drop the oldest element. A consumer may have read it already in the meantime, so don't block
+			select {
+			case <-r.c:
+			default:
+			}
+		}
+	}
+}
+
+//Out returns the channel to consume elements from
+func (r *RingChan[T]) Out() <-chan T {
+	return r.c
+}
+
+//Len returns the number of buffered elements
+func (r *RingChan[T]) Len() int {
+	return len(r.c)
+}
+
+//Close closes the output channel, buffered elements can still be consumed. Closing twice is safe
+func (r *RingChan[T]) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.closed {
+		r.closed = true
+		close(r.c)
+	}
+}
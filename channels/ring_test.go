@@ -0,0 +1,23 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+)
+
+func TestRingChan(t *testing.T) {
+	ring := channels.NewRingChan[int](3)
+	for i := 1; i <= 5; i++ {
+		ring.Send(i) //Never blocks although nobody is consuming
+	}
+	assert.Equal(t, 3, ring.Len())
+	ring.Close()
+	ring.Send(6) //No panic after close
+
+	var result []int
+	for v := range ring.Out() {
+		result = append(result, v)
+	}
+	assert.Equal(t, []int{3, 4, 5}, result) //1 and 2 were evicted
+}
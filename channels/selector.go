@@ -0,0 +1,95 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+//ErrNoChannels is returned by Selector.Recv when there is no open channel left to receive from
+var ErrNoChannels = errors.New("no channels to receive from")
+
+//Selector receives from a set of channels that can change at runtime. A select statement needs its cases at compile
+//time, so the Selector uses reflect.Select instead
+type Selector[T any] struct {
+	mu      sync.Mutex
+	chans   []<-chan T
+	changed chan struct{}
+}
+
+func NewSelector[T any]() *Selector[T] {
+	return &Selector[T]{
+		changed: make(chan struct{}, 1),
+	}
+}
+
+//Add registers c. Closed channels are removed automatically
+func (s *Selector[T]) Add(c <-chan T) {
+	s.mu.Lock()
+	s.chans = append(s.chans, c)
+	s.mu.Unlock()
+	s.notify()
+}
+
+//Remove unregisters c
+func (s *Selector[T]) Remove(c <-chan T) {
+	s.mu.Lock()
+	for i := range s.chans {
+		if s.chans[i] == c {
+			s.chans = append(s.chans[:i], s.chans[i+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+	s.notify()
+}
+
+//Len returns the number of registered channels
+func (s *Selector[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.chans)
+}
+
+//notify wakes up a blocked Recv, so it picks up the changed set of channels
+func (s *Selector[T]) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+//Recv blocks until any registered channel has a value. It returns ErrNoChannels if no open channel is registered,
+//or the context error if ctx is cancelled
+func (s *Selector[T]) Recv(ctx context.Context) (T, error) {
+	var zero T
+	for {
+		s.mu.Lock()
+		chans := append([]<-chan T(nil), s.chans...)
+		s.mu.Unlock()
+		if len(chans) == 0 {
+			return zero, ErrNoChannels
+		}
+
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.changed)},
+		}
+		for _, c := range chans {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c)})
+		}
+		chosen, value, ok := reflect.Select(cases)
+		switch {
+		case chosen == 0:
+			return zero, ctx.Err()
+		case chosen == 1:
+			continue //The set of channels changed, start over
+		case !ok:
+			s.Remove(chans[chosen-2])
+		default:
+			v, _ := value.Interface().(T) //A nil interface value converts to the zero T instead of panicking
+			return v, nil
+		}
+	}
+}
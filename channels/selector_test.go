@@ -0,0 +1,64 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestSelector(t *testing.T) {
+	ctx := context.Background()
+	s := channels.NewSelector[int]()
+	_, err := s.Recv(ctx)
+	assert.Equal(t, channels.ErrNoChannels, err)
+
+	//The number of producers is only known at runtime
+	for i := 0; i < 3; i++ {
+		c := make(chan int, 1)
+		c <- i
+		close(c)
+		s.Add(c)
+	}
+	sum := 0
+	for {
+		value, err := s.Recv(ctx)
+		if err == channels.ErrNoChannels {
+			break //All channels are closed and removed
+		}
+		sum += value
+	}
+	assert.Equal(t, 0+1+2, sum)
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestSelectorAddWhileBlocked(t *testing.T) {
+	s := channels.NewSelector[string]()
+	s.Add(make(chan string)) //Never delivers
+
+	late := make(chan string, 1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		late <- "late"
+		s.Add(late)
+	}()
+	value, err := s.Recv(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "late", value)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = s.Recv(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestSelectorNilInterface(t *testing.T) {
+	s := channels.NewSelector[error]()
+	c := make(chan error, 1)
+	c <- nil
+	s.Add(c)
+	err, recvErr := s.Recv(context.Background())
+	assert.Nil(t, recvErr)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,48 @@
+package channels
+
+import "context"
+
+//Semaphore limits the number of concurrent holders using a buffered channel. Acquiring writes into the channel,
+//which blocks once the buffer is full, and releasing reads from it
+type Semaphore struct {
+	slots chan struct{}
+}
+
+//NewSemaphore creates a Semaphore allowing up to n concurrent holders
+func NewSemaphore(n int) *Semaphore {
+	if n < 1 {
+		n = 1
+	}
+	return &Semaphore{
+		slots: make(chan struct{}, n),
+	}
+}
+
+//Acquire blocks until a slot is available or ctx is cancelled, in which case the context error is returned
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//TryAcquire acquires a slot without blocking and reports whether it succeeded
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+//Release frees a slot. Releasing more often than acquiring is a programming error and panics
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+	default:
+		panic("channels: semaphore released without acquire")
+	}
+}
@@ -0,0 +1,27 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestSemaphore(t *testing.T) {
+	sem := channels.NewSemaphore(2)
+	assert.Nil(t, sem.Acquire(context.Background()))
+	assert.True(t, sem.TryAcquire())
+	assert.False(t, sem.TryAcquire()) //Both slots taken
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, sem.Acquire(ctx))
+
+	sem.Release()
+	assert.True(t, sem.TryAcquire())
+
+	sem.Release()
+	sem.Release()
+	assert.Panics(t, sem.Release)
+}
@@ -0,0 +1,105 @@
+package channels
+
+import (
+	"fmt"
+	"time"
+)
+
+//Debounce emits a value only after no newer value arrived on in for the duration d. Bursts of values are collapsed into
+//their last element. A pending value is flushed when in is closed, then the output channel is closed
+func Debounce[T any](in <-chan T, d time.Duration) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		var pending T
+		var hasPending bool
+		timer := time.NewTimer(d)
+		timer.Stop()
+		for {
+			select {
+			case value, ok := <-in:
+				if !ok {
+					timer.Stop()
+					if hasPending {
+						output <- pending
+					}
+					return
+				}
+				pending, hasPending = value, true
+				//Every new value restarts the quiet period
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(d)
+			case <-timer.C:
+				if hasPending {
+					output <- pending
+					hasPending = false
+				}
+			}
+		}
+	}()
+	return output
+}
+
+//Throttle lets at most rate values of in pass per time window of length per. Values exceeding the rate within a window
+//are discarded, so the producer is never slowed down. The output channel is closed when in is closed
+func Throttle[T any](in <-chan T, rate int, per time.Duration) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		windowStart := time.Now()
+		count := 0
+		for value := range in {
+			if now := time.Now(); now.Sub(windowStart) >= per {
+				windowStart, count = now, 0 //Start a new window
+			}
+			if count >= rate {
+				continue //Over the limit, discard
+			}
+			count++
+			output <- value
+		}
+	}()
+	return output
+}
+
+//RateLimit forwards the values of in at a rate of at most n values per time window of length per, using a token
+//bucket. Bursts of up to n values pass immediately, after that values are delayed, never discarded. Compare with
+//Throttle, which discards values instead. The output channel is closed when in is closed. RateLimit panics if n or
+//per is not positive, as no rate could be met
+func RateLimit[T any](in <-chan T, n int, per time.Duration) <-chan T {
+	if n < 1 || per <= 0 {
+		panic(fmt.Sprintf("channels: invalid rate limit of %d values per %s", n, per))
+	}
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		refill := per / time.Duration(n) //Time to earn one token
+		if refill <= 0 {
+			refill = 1 //More than one value per nanosecond, wait as little as possible
+		}
+		tokens := float64(n)
+		last := time.Now()
+		for value := range in {
+			now := time.Now()
+			tokens += float64(now.Sub(last)) / float64(refill)
+			if tokens > float64(n) {
+				tokens = float64(n) //The bucket never holds more than n tokens
+			}
+			last = now
+			if tokens < 1 {
+				wait := time.Duration((1 - tokens) * float64(refill))
+				time.Sleep(wait)
+				tokens = 1
+				last = time.Now()
+			}
+			tokens--
+			output <- value
+		}
+	}()
+	return output
+}
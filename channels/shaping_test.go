@@ -0,0 +1,70 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestDebounce(t *testing.T) {
+	in := make(chan int)
+	out := channels.Debounce[int](in, 20*time.Millisecond)
+
+	//A burst is collapsed into its last element
+	in <- 1
+	in <- 2
+	in <- 3
+	assert.Equal(t, 3, <-out)
+
+	//The pending value is flushed on close
+	in <- 4
+	close(in)
+	assert.Equal(t, 4, <-out)
+	_, ok := <-out
+	assert.False(t, ok)
+}
+
+func TestThrottle(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		for i := 0; i < 10; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	var passed []int
+	for v := range channels.Throttle[int](in, 3, time.Hour) {
+		passed = append(passed, v)
+	}
+	assert.Equal(t, []int{0, 1, 2}, passed) //Everything beyond the rate within the window is discarded
+}
+
+func TestRateLimit(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		for i := 0; i < 6; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	start := time.Now()
+	var passed []int
+	for v := range channels.RateLimit[int](in, 3, 30*time.Millisecond) {
+		passed = append(passed, v)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, passed) //Nothing is discarded
+	//The first 3 pass as a burst, the next 3 need one token each, earned every 10ms
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestRateLimitInvalid(t *testing.T) {
+	assert.PanicsWithValue(t, "channels: invalid rate limit of 0 values per 1s", func() {
+		channels.RateLimit[int](nil, 0, time.Second)
+	})
+	assert.PanicsWithValue(t, "channels: invalid rate limit of 3 values per 0s", func() {
+		channels.RateLimit[int](nil, 3, 0)
+	})
+}
@@ -0,0 +1,68 @@
+package channels
+
+import (
+	"context"
+	"sync"
+)
+
+//Shutdown coordinates the ordered shutdown of a channel pipeline: first all producers are stopped, then the consumers
+//get time to finish processing the items in flight, and finally the registered outputs are closed
+type Shutdown struct {
+	mu        sync.Mutex
+	producers []context.CancelFunc
+	consumers []<-chan struct{}
+	closers   []func()
+}
+
+func NewShutdown() *Shutdown {
+	return &Shutdown{}
+}
+
+//AddProducer registers the cancel function stopping a producer
+func (s *Shutdown) AddProducer(cancel context.CancelFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.producers = append(s.producers, cancel)
+}
+
+//AddConsumer registers a channel that is closed once a consumer processed all of its items
+func (s *Shutdown) AddConsumer(done <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.consumers = append(s.consumers, done)
+}
+
+//AddCloser registers a function closing an output, e.g. a channel exposed to package users
+func (s *Shutdown) AddCloser(closer func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closers = append(s.closers, closer)
+}
+
+//Run cancels all producers and waits for all consumers to be done, or for ctx to expire. The closers are invoked
+//in either case, in registration order. If ctx expired before all consumers were done, the context error is returned
+func (s *Shutdown) Run(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cancel := range s.producers {
+		cancel()
+	}
+
+	var err error
+	for _, done := range s.consumers {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if err != nil {
+			break //Deadline reached, don't wait for the remaining consumers
+		}
+	}
+
+	for _, closer := range s.closers {
+		closer()
+	}
+	return err
+}
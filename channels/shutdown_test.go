@@ -0,0 +1,64 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	producerCtx, stopProducer := context.WithCancel(context.Background())
+	numbers := make(chan int)
+	produced := 0
+	go func() {
+		defer close(numbers)
+		for {
+			select {
+			case numbers <- produced:
+				produced++
+			case <-producerCtx.Done():
+				return
+			}
+		}
+	}()
+
+	processed := 0
+	started := make(chan struct{})
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
+		for range numbers { //Ends when the producer is stopped and closed its channel
+			processed++
+			if processed == 1 {
+				close(started)
+			}
+		}
+	}()
+
+	processedAtClose := -1
+	s := channels.NewShutdown()
+	s.AddProducer(stopProducer)
+	s.AddConsumer(consumerDone)
+	s.AddCloser(func() { processedAtClose = processed })
+
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Nil(t, s.Run(ctx))
+	assert.Greater(t, processed, 0)
+	assert.Equal(t, produced, processedAtClose) //Every produced value was consumed before the closer ran
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	closed := false
+	s := channels.NewShutdown()
+	s.AddConsumer(make(chan struct{})) //A consumer that never finishes
+	s.AddCloser(func() { closed = true })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, s.Run(ctx))
+	assert.True(t, closed) //Outputs are closed regardless
+}
@@ -0,0 +1,116 @@
+package channels
+
+import "context"
+
+//Map applies fn to every value of in and sends the result to the output channel.
+//Like all pipeline stages, the output channel is closed when in is closed or ctx is cancelled
+func Map[T, R any](ctx context.Context, in <-chan T, fn func(T) R) <-chan R {
+	output := make(chan R)
+	go func() {
+		defer close(output)
+		for value := range orDone(ctx, in) {
+			select {
+			case output <- fn(value):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return output
+}
+
+//MapE works like Map for functions that can fail. Every outcome is sent as a Result, so errors propagate downstream
+func MapE[T, R any](ctx context.Context, in <-chan T, fn func(T) (R, error)) <-chan Result[R] {
+	return Map(ctx, in, func(value T) Result[R] {
+		r, err := fn(value)
+		return Result[R]{Value: r, Err: err}
+	})
+}
+
+//Filter forwards only the values of in for which keep returns true
+func Filter[T any](ctx context.Context, in <-chan T, keep func(T) bool) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		for value := range orDone(ctx, in) {
+			if !keep(value) {
+				continue
+			}
+			select {
+			case output <- value:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return output
+}
+
+//Take forwards the first n values of in, then closes the output channel. The remaining values of in are not consumed
+func Take[T any](ctx context.Context, in <-chan T, n int) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		for i := 0; i < n; i++ {
+			select {
+			case value, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case output <- value:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return output
+}
+
+//Skip discards the first n values of in and forwards the rest
+func Skip[T any](ctx context.Context, in <-chan T, n int) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		skipped := 0
+		for value := range orDone(ctx, in) {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			select {
+			case output <- value:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return output
+}
+
+//orDone wraps in so that ranging over the result also stops when ctx is cancelled
+func orDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	output := make(chan T)
+	go func() {
+		defer close(output)
+		for {
+			select {
+			case value, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case output <- value:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return output
+}
@@ -0,0 +1,42 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"strconv"
+	"testing"
+)
+
+func numbers(n int) <-chan int {
+	output := make(chan int)
+	go func() {
+		defer close(output)
+		for i := 0; i < n; i++ {
+			output <- i
+		}
+	}()
+	return output
+}
+
+func TestPipelineStages(t *testing.T) {
+	ctx := context.Background()
+	//Compose the stages: skip 2, keep even numbers, take 3, convert to string
+	even := channels.Filter(ctx, channels.Skip(ctx, numbers(20), 2), func(i int) bool { return i%2 == 0 })
+	strs := channels.Map(ctx, channels.Take(ctx, even, 3), strconv.Itoa)
+
+	var result []string
+	for s := range strs {
+		result = append(result, s)
+	}
+	assert.Equal(t, []string{"2", "4", "6"}, result)
+}
+
+func TestPipelineStagesCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int) //Never closed
+	mapped := channels.Map(ctx, in, func(i int) int { return i })
+	cancel()
+	_, ok := <-mapped
+	assert.False(t, ok)
+}
@@ -0,0 +1,40 @@
+package channels
+
+import "context"
+
+//Tee duplicates every value of in onto the two returned channels. Each value is sent to both outputs before
+//the next value is read, so the slower consumer sets the pace. Both outputs are closed when in is closed or ctx is cancelled
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	out1 := make(chan T)
+	out2 := make(chan T)
+	go func() {
+		defer close(out1)
+		defer close(out2)
+		for {
+			var value T
+			var ok bool
+			select {
+			case value, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			//Use local copies so a channel can be set to nil once it received the value. Sending on a nil channel blocks
+			//forever, which disables that select case
+			o1, o2 := out1, out2
+			for i := 0; i < 2; i++ {
+				select {
+				case o1 <- value:
+					o1 = nil
+				case o2 <- value:
+					o2 = nil
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out1, out2
+}
@@ -0,0 +1,50 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"sync"
+	"testing"
+)
+
+func TestTee(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		for i := 0; i < 5; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	logged, processed := channels.Tee[int](context.Background(), in)
+	var first, second []int
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for v := range logged {
+			first = append(first, v)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for v := range processed {
+			second = append(second, v)
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, first)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, second)
+}
+
+func TestTeeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out1, out2 := channels.Tee[int](ctx, make(chan int))
+	cancel()
+	_, ok1 := <-out1
+	_, ok2 := <-out2
+	assert.False(t, ok1)
+	assert.False(t, ok2)
+}
@@ -0,0 +1,70 @@
+package channels
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+type tickConfig struct {
+	jitter    time.Duration
+	immediate bool
+}
+
+type TickOption func(*tickConfig)
+
+//WithJitter adds a random duration in [0, jitter) to every interval. This spreads out the ticks of many instances
+//started at the same time, so they don't hit a shared resource simultaneously
+func WithJitter(jitter time.Duration) TickOption {
+	return func(c *tickConfig) {
+		c.jitter = jitter
+	}
+}
+
+//WithImmediateTick sends the first tick right away instead of after the first interval
+func WithImmediateTick() TickOption {
+	return func(c *tickConfig) {
+		c.immediate = true
+	}
+}
+
+//Tick sends the current time every interval until ctx is cancelled, then the channel is closed.
+//Like time.Ticker, ticks are dropped if the consumer is not ready to receive them
+func Tick(ctx context.Context, interval time.Duration, opts ...TickOption) <-chan time.Time {
+	config := tickConfig{}
+	for idx := range opts {
+		opts[idx](&config)
+	}
+	output := make(chan time.Time, 1)
+	send := func(t time.Time) {
+		select {
+		case output <- t:
+		default: //Consumer is busy, drop the tick
+		}
+	}
+	go func() {
+		defer close(output)
+		if config.immediate {
+			send(time.Now())
+		}
+		timer := time.NewTimer(tickInterval(interval, config.jitter))
+		defer timer.Stop()
+		for {
+			select {
+			case t := <-timer.C:
+				send(t)
+				timer.Reset(tickInterval(interval, config.jitter))
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return output
+}
+
+func tickInterval(interval, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return interval
+	}
+	return interval + time.Duration(rand.Int63n(int64(jitter)))
+}
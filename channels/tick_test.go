@@ -0,0 +1,35 @@
+package channels_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestTick(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	start := time.Now()
+	ticks := channels.Tick(ctx, 10*time.Millisecond, channels.WithJitter(5*time.Millisecond))
+
+	<-ticks
+	<-ticks
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	cancel()
+	for range ticks { //Closed after cancellation
+	}
+}
+
+func TestTickImmediate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticks := channels.Tick(ctx, time.Hour, channels.WithImmediateTick())
+
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		t.Fatal("expected an immediate tick")
+	}
+}
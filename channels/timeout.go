@@ -0,0 +1,29 @@
+package channels
+
+import "time"
+
+//RecvTimeout receives from c, waiting at most d. It returns false if the timeout expired or c is closed.
+//Unlike time.After, the timer is stopped as soon as a value arrives, so it doesn't linger until it fires
+func RecvTimeout[T any](c <-chan T, d time.Duration) (T, bool) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case value, ok := <-c:
+		return value, ok
+	case <-timer.C:
+		var zero T
+		return zero, false
+	}
+}
+
+//SendTimeout sends value to c, waiting at most d for a receiver. It returns false if the value was not sent
+func SendTimeout[T any](c chan<- T, value T, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case c <- value:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
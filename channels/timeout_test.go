@@ -0,0 +1,33 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+	"time"
+)
+
+func TestRecvTimeout(t *testing.T) {
+	c := make(chan int, 1)
+	_, ok := channels.RecvTimeout(c, time.Millisecond)
+	assert.False(t, ok) //Nothing to receive
+
+	c <- 5
+	value, ok := channels.RecvTimeout(c, time.Millisecond)
+	assert.True(t, ok)
+	assert.Equal(t, 5, value)
+
+	close(c)
+	_, ok = channels.RecvTimeout(c, time.Second)
+	assert.False(t, ok) //Closed channel returns immediately
+}
+
+func TestSendTimeout(t *testing.T) {
+	c := make(chan int)
+	assert.False(t, channels.SendTimeout(c, 5, time.Millisecond)) //No receiver
+
+	go func() {
+		<-c
+	}()
+	assert.True(t, channels.SendTimeout(c, 5, time.Second))
+}
@@ -0,0 +1,55 @@
+package channels
+
+//UnboundedChan decouples producers from consumers with an internal growable queue, so sends on In never block.
+//Close In when done sending, Out is closed after all queued elements were consumed
+type UnboundedChan[T any] struct {
+	in  chan T
+	out chan T
+}
+
+//Unbounded creates an UnboundedChan. Use it with care, a consumer that never keeps up lets the queue grow without limit
+func Unbounded[T any]() *UnboundedChan[T] {
+	u := &UnboundedChan[T]{
+		in:  make(chan T),
+		out: make(chan T),
+	}
+	go u.run()
+	return u
+}
+
+//In returns the channel to send elements to. The caller owns its lifecycle and must close it when done
+func (u *UnboundedChan[T]) In() chan<- T {
+	return u.in
+}
+
+//Out returns the channel to consume elements from
+func (u *UnboundedChan[T]) Out() <-chan T {
+	return u.out
+}
+
+func (u *UnboundedChan[T]) run() {
+	defer close(u.out)
+	var queue []T
+	in := u.in
+	for in != nil || len(queue) > 0 {
+		//Only enable the send case while there is something queued, sending on a nil channel blocks forever
+		var out chan T
+		var next T
+		if len(queue) > 0 {
+			out = u.out
+			next = queue[0]
+		}
+		select {
+		case value, ok := <-in:
+			if !ok {
+				in = nil //Input closed, keep draining the queue
+				continue
+			}
+			queue = append(queue, value)
+		case out <- next:
+			var zero T
+			queue[0] = zero //Allow the element to be garbage collected
+			queue = queue[1:]
+		}
+	}
+}
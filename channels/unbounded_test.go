@@ -0,0 +1,22 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+)
+
+func TestUnbounded(t *testing.T) {
+	u := channels.Unbounded[int]()
+	for i := 0; i < 1000; i++ {
+		u.In() <- i //Never blocks, although nobody consumes yet
+	}
+	close(u.In())
+
+	count := 0
+	for v := range u.Out() {
+		assert.Equal(t, count, v) //FIFO order
+		count++
+	}
+	assert.Equal(t, 1000, count)
+}
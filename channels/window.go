@@ -0,0 +1,44 @@
+package channels
+
+//Window emits sliding windows of size elements over in, advancing by step elements per window. With step < size the
+//windows overlap, which is useful for moving averages. When in is closed, the elements received since the last
+//window are flushed as a final partial window and the output channel is closed
+func Window[T any](in <-chan T, size int, step int) <-chan []T {
+	if size < 1 {
+		size = 1
+	}
+	if step < 1 {
+		step = 1
+	}
+	output := make(chan []T)
+	go func() {
+		defer close(output)
+		var window []T
+		skip := 0      //Elements to discard when step is larger than size
+		fresh := false //Whether window holds elements not emitted yet
+		for value := range in {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			window = append(window, value)
+			fresh = true
+			if len(window) < size {
+				continue
+			}
+			//Every emitted window is a copy, the consumer may keep it while we continue sliding
+			output <- append([]T(nil), window...)
+			fresh = false
+			if step >= size {
+				skip = step - size
+				window = nil
+			} else {
+				window = append([]T(nil), window[step:]...)
+			}
+		}
+		if fresh && len(window) > 0 {
+			output <- window
+		}
+	}()
+	return output
+}
@@ -0,0 +1,45 @@
+package channels_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"testing"
+)
+
+func TestWindow(t *testing.T) {
+	var tests = []struct {
+		Name           string
+		Size           int
+		Step           int
+		ExpectedOutput [][]int
+	}{
+		{
+			Name:           "Overlapping",
+			Size:           3,
+			Step:           1,
+			ExpectedOutput: [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}},
+		},
+		{
+			Name:           "Tumbling with partial flush",
+			Size:           2,
+			Step:           2,
+			ExpectedOutput: [][]int{{0, 1}, {2, 3}, {4}},
+		},
+		{
+			Name:           "Gaps",
+			Size:           2,
+			Step:           3,
+			ExpectedOutput: [][]int{{0, 1}, {3, 4}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var windows [][]int
+			for w := range channels.Window(numbers(5), test.Size, test.Step) {
+				windows = append(windows, w)
+			}
+			assert.Equal(t, test.ExpectedOutput, windows)
+		})
+	}
+}
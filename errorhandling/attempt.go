@@ -0,0 +1,48 @@
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type attemptError struct {
+	err      error
+	attempts int
+	elapsed  time.Duration
+}
+
+func (e attemptError) Error() string {
+	return fmt.Sprintf("%s (after %d attempts in %s)", e.err.Error(), e.attempts, e.elapsed)
+}
+
+func (e attemptError) Unwrap() error {
+	return e.err
+}
+
+//WithAttempt annotates err with the number of attempts a retry wrapper made and the time it spent before giving up.
+//The message is suffixed uniformly, e.g. "connection failed (after 3 attempts in 1.5s)". nil stays nil
+func WithAttempt(err error, attempts int, elapsed time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return attemptError{err: err, attempts: attempts, elapsed: elapsed}
+}
+
+//Attempts returns the number of attempts attached to the chain of err, or 0 if there is none
+func Attempts(err error) int {
+	var ae attemptError
+	if errors.As(err, &ae) {
+		return ae.attempts
+	}
+	return 0
+}
+
+//Elapsed returns the time spent on the attempts attached to the chain of err, or 0 if there is none
+func Elapsed(err error) time.Duration {
+	var ae attemptError
+	if errors.As(err, &ae) {
+		return ae.elapsed
+	}
+	return 0
+}
@@ -0,0 +1,41 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+	"time"
+)
+
+//retry calls fn up to attempts times and annotates the final error
+func retry(attempts int, fn func() error) error {
+	start := time.Now()
+	var err error
+	for i := 1; i <= attempts; i++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+	}
+	return errorhandling.WithAttempt(err, attempts, time.Since(start))
+}
+
+func TestWithAttempt(t *testing.T) {
+	err := retry(3, errorhandling.ReturnPredefinedError)
+	err = fmt.Errorf("syncing: %w", err)
+
+	assert.Equal(t, 3, errorhandling.Attempts(err))
+	assert.Greater(t, errorhandling.Elapsed(err), time.Duration(0))
+	assert.Contains(t, err.Error(), "syncing: connection failed (after 3 attempts in ")
+	assert.True(t, errors.Is(err, errorhandling.ConnectionError))
+
+	assert.Equal(t, 0, errorhandling.Attempts(errorhandling.ConnectionError))
+	assert.Nil(t, errorhandling.WithAttempt(nil, 1, time.Second))
+}
+
+func TestWithAttemptFixedValues(t *testing.T) {
+	err := errorhandling.WithAttempt(errorhandling.ConnectionError, 2, 1500*time.Millisecond)
+	assert.Equal(t, "connection failed (after 2 attempts in 1.5s)", err.Error())
+	assert.Equal(t, 1500*time.Millisecond, errorhandling.Elapsed(err))
+}
@@ -0,0 +1,94 @@
+package errorhandling
+
+import (
+	"sync"
+	"time"
+)
+
+const budgetBuckets = 10
+
+type budgetBucket struct {
+	start     time.Time
+	successes int
+	failures  int
+}
+
+//Budget tracks the error rate over a sliding time window. Callers use it to raise alarms or degrade functionality
+//once too many operations fail. The window is divided into buckets, old buckets are discarded as time passes
+type Budget struct {
+	mu       sync.Mutex
+	window   time.Duration
+	maxRatio float64
+	buckets  []budgetBucket
+}
+
+//NewBudget creates a Budget over the given window. It is exceeded once the ratio of failures exceeds maxRatio
+func NewBudget(window time.Duration, maxRatio float64) *Budget {
+	return &Budget{
+		window:   window,
+		maxRatio: maxRatio,
+	}
+}
+
+//Record records the outcome of an operation, a nil err counts as success
+func (b *Budget) Record(err error) {
+	if err == nil {
+		b.RecordSuccess()
+	} else {
+		b.RecordFailure()
+	}
+}
+
+func (b *Budget) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current().successes++
+}
+
+func (b *Budget) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current().failures++
+}
+
+//Ratio returns the ratio of failures to all recorded operations within the window, 0 if there were none
+func (b *Budget) Ratio() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expire(time.Now())
+	successes, failures := 0, 0
+	for _, bucket := range b.buckets {
+		successes += bucket.successes
+		failures += bucket.failures
+	}
+	if successes+failures == 0 {
+		return 0
+	}
+	return float64(failures) / float64(successes+failures)
+}
+
+//Exceeded reports whether the failure ratio within the window is above the configured maximum
+func (b *Budget) Exceeded() bool {
+	return b.Ratio() > b.maxRatio
+}
+
+//current returns the bucket for the current time, creating it if needed. Must be called with the lock held
+func (b *Budget) current() *budgetBucket {
+	now := time.Now()
+	b.expire(now)
+	width := b.window / budgetBuckets
+	if n := len(b.buckets); n > 0 && now.Sub(b.buckets[n-1].start) < width {
+		return &b.buckets[n-1]
+	}
+	b.buckets = append(b.buckets, budgetBucket{start: now})
+	return &b.buckets[len(b.buckets)-1]
+}
+
+//expire drops the buckets that slid out of the window. Must be called with the lock held
+func (b *Budget) expire(now time.Time) {
+	i := 0
+	for i < len(b.buckets) && now.Sub(b.buckets[i].start) >= b.window {
+		i++
+	}
+	b.buckets = b.buckets[i:]
+}
@@ -0,0 +1,26 @@
+package errorhandling_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+	"time"
+)
+
+func TestBudget(t *testing.T) {
+	budget := errorhandling.NewBudget(50*time.Millisecond, 0.5)
+	assert.Equal(t, 0.0, budget.Ratio())
+
+	budget.RecordSuccess()
+	budget.Record(errorhandling.ConnectionError)
+	assert.Equal(t, 0.5, budget.Ratio())
+	assert.False(t, budget.Exceeded())
+
+	budget.RecordFailure()
+	assert.InDelta(t, 0.66, budget.Ratio(), 0.01)
+	assert.True(t, budget.Exceeded())
+
+	time.Sleep(60 * time.Millisecond) //All outcomes slide out of the window
+	assert.Equal(t, 0.0, budget.Ratio())
+	assert.False(t, budget.Exceeded())
+}
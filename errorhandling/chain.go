@@ -0,0 +1,45 @@
+package errorhandling
+
+import "strings"
+
+//Chain returns err and all errors in its chain, outermost first. Joined errors are included depth first
+func Chain(err error) []error {
+	var chain []error
+	walk(err, func(e error) {
+		chain = append(chain, e)
+	})
+	return chain
+}
+
+//Format renders the chain of err on multiple lines, every cause indented one level deeper than the error wrapping it.
+//This is easier to read in logs than the single line message of deeply wrapped errors
+func Format(err error) string {
+	if err == nil {
+		return ""
+	}
+	b := strings.Builder{}
+	format(&b, err, 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func format(b *strings.Builder, err error, depth int) {
+	prefix := strings.Repeat("  ", depth)
+	if depth > 0 {
+		prefix += "caused by: "
+	}
+	//Messages of joined errors span multiple lines, align them with the first line
+	continuation := "\n" + strings.Repeat(" ", len(prefix))
+	b.WriteString(prefix)
+	b.WriteString(strings.ReplaceAll(err.Error(), "\n", continuation))
+	b.WriteString("\n")
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			format(b, inner, depth+1)
+		}
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			format(b, inner, depth+1)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	err := errorhandling.ReturnCustomError()
+	wrapErr := fmt.Errorf("I caught an error %w", err)
+
+	assert.Equal(t, []error{wrapErr, err}, errorhandling.Chain(wrapErr))
+	assert.Nil(t, errorhandling.Chain(nil))
+}
+
+func TestFormat(t *testing.T) {
+	joined := errors.Join(
+		fmt.Errorf("db: %w", errorhandling.ConnectionError),
+		errors.New("cache miss"),
+	)
+	err := fmt.Errorf("loading profile: %w", joined)
+
+	expected := `loading profile: db: connection failed
+cache miss
+  caused by: db: connection failed
+             cache miss
+    caused by: db: connection failed
+      caused by: connection failed
+    caused by: cache miss`
+	assert.Equal(t, expected, errorhandling.Format(err))
+}
@@ -0,0 +1,114 @@
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+)
+
+//Code categorizes errors, so callers can react to the kind of failure without matching error strings
+type Code int
+
+const (
+	CodeOK Code = iota
+	CodeUnknown
+	CodeInvalidArgument
+	CodeNotFound
+	CodeConflict
+	CodePermissionDenied
+	CodeUnauthenticated
+	CodeUnavailable
+	CodeDeadlineExceeded
+	CodeInternal
+)
+
+var codeNames = map[Code]string{
+	CodeOK:               "ok",
+	CodeUnknown:          "unknown",
+	CodeInvalidArgument:  "invalid argument",
+	CodeNotFound:         "not found",
+	CodeConflict:         "conflict",
+	CodePermissionDenied: "permission denied",
+	CodeUnauthenticated:  "unauthenticated",
+	CodeUnavailable:      "unavailable",
+	CodeDeadlineExceeded: "deadline exceeded",
+	CodeInternal:         "internal",
+}
+
+func (c Code) String() string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("code(%d)", int(c))
+}
+
+//Coded is an error carrying a Code. Like CustomError it is used as value, not as pointer
+type Coded struct {
+	Code    Code
+	Message string
+	Err     error //Wrapped cause, may be nil
+}
+
+//Error satisfies the error interface
+func (e Coded) Error() string {
+	return e.Message
+}
+
+//Unwrap returns the wrapped cause, so errors.Is() and errors.As() examine it as well
+func (e Coded) Unwrap() error {
+	return e.Err
+}
+
+//Newf creates a Coded error. The format supports '%w' to wrap a cause, like fmt.Errorf
+func Newf(code Code, format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	return Coded{
+		Code:    code,
+		Message: err.Error(),
+		Err:     errors.Unwrap(err),
+	}
+}
+
+func InvalidArgument(format string, args ...interface{}) error {
+	return Newf(CodeInvalidArgument, format, args...)
+}
+
+func NotFound(format string, args ...interface{}) error {
+	return Newf(CodeNotFound, format, args...)
+}
+
+func Conflict(format string, args ...interface{}) error {
+	return Newf(CodeConflict, format, args...)
+}
+
+func PermissionDenied(format string, args ...interface{}) error {
+	return Newf(CodePermissionDenied, format, args...)
+}
+
+func Unauthenticated(format string, args ...interface{}) error {
+	return Newf(CodeUnauthenticated, format, args...)
+}
+
+func Unavailable(format string, args ...interface{}) error {
+	return Newf(CodeUnavailable, format, args...)
+}
+
+func DeadlineExceeded(format string, args ...interface{}) error {
+	return Newf(CodeDeadlineExceeded, format, args...)
+}
+
+func Internal(format string, args ...interface{}) error {
+	return Newf(CodeInternal, format, args...)
+}
+
+//CodeOf returns the Code of the first Coded error in the chain of err. It returns CodeOK for nil and CodeUnknown if
+//the chain contains no Coded error
+func CodeOf(err error) Code {
+	if err == nil {
+		return CodeOK
+	}
+	var coded Coded
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return CodeUnknown
+}
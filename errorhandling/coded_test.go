@@ -0,0 +1,41 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func TestCodedError(t *testing.T) {
+	err := errorhandling.NotFound("user %s", "42")
+	assert.Equal(t, "user 42", err.Error())
+	assert.Equal(t, errorhandling.CodeNotFound, errorhandling.CodeOf(err))
+
+	//CodeOf walks the error chain
+	wrapped := fmt.Errorf("loading profile: %w", err)
+	assert.Equal(t, errorhandling.CodeNotFound, errorhandling.CodeOf(wrapped))
+
+	//Branch on the category instead of matching strings
+	switch errorhandling.CodeOf(wrapped) {
+	case errorhandling.CodeNotFound:
+	default:
+		t.Fatal("unexpected error")
+	}
+
+	assert.Equal(t, errorhandling.CodeOK, errorhandling.CodeOf(nil))
+	assert.Equal(t, errorhandling.CodeUnknown, errorhandling.CodeOf(errors.New("plain")))
+	assert.Equal(t, "not found", errorhandling.CodeNotFound.String())
+}
+
+func TestCodedErrorWrapsCause(t *testing.T) {
+	err := errorhandling.Unavailable("database: %w", errorhandling.ConnectionError)
+	assert.Equal(t, "database: connection failed", err.Error())
+	assert.True(t, errors.Is(err, errorhandling.ConnectionError))
+
+	var coded errorhandling.Coded
+	if assert.True(t, errors.As(err, &coded)) {
+		assert.Equal(t, errorhandling.CodeUnavailable, coded.Code)
+	}
+}
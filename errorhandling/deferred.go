@@ -0,0 +1,32 @@
+package errorhandling
+
+import "io"
+
+//AppendInto adds newErr to the error *into points to. If *into is nil, it becomes newErr, otherwise both are combined
+//into a MultiError. It returns whether newErr was non-nil
+func AppendInto(into *error, newErr error) bool {
+	if newErr == nil {
+		return false
+	}
+	if *into == nil {
+		*into = newErr
+		return true
+	}
+	*into = (&MultiError{}).Append(*into, newErr)
+	return true
+}
+
+//DeferClose closes closer and appends its error to *err. Use it with a named error return value, so the error of a
+//deferred Close() isn't silently lost:
+//
+//	func write(path string) (err error) {
+//		f, err := os.Create(path)
+//		if err != nil {
+//			return err
+//		}
+//		defer errorhandling.DeferClose(&err, f)
+//		...
+//	}
+func DeferClose(err *error, closer io.Closer) {
+	AppendInto(err, closer.Close())
+}
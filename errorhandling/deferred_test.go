@@ -0,0 +1,40 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+type closer struct {
+	err error
+}
+
+func (c closer) Close() error {
+	return c.err
+}
+
+func useResource(c closer, workErr error) (err error) {
+	defer errorhandling.DeferClose(&err, c)
+	return workErr
+}
+
+func TestDeferClose(t *testing.T) {
+	closeErr := errors.New("close failed")
+
+	assert.Nil(t, useResource(closer{}, nil))
+	assert.Equal(t, closeErr, useResource(closer{err: closeErr}, nil)) //Not lost although the function returned nil
+
+	err := useResource(closer{err: closeErr}, errorhandling.ConnectionError)
+	assert.True(t, errors.Is(err, closeErr)) //Both errors are preserved
+	assert.True(t, errors.Is(err, errorhandling.ConnectionError))
+}
+
+func TestAppendInto(t *testing.T) {
+	var err error
+	assert.False(t, errorhandling.AppendInto(&err, nil))
+	assert.Nil(t, err)
+	assert.True(t, errorhandling.AppendInto(&err, errorhandling.ConnectionError))
+	assert.Equal(t, errorhandling.ConnectionError, err)
+}
@@ -0,0 +1,37 @@
+package errorhandling
+
+import "reflect"
+
+//Ensure returns a CodeInvalidArgument error with the given message if cond is false, otherwise nil.
+//It expresses preconditions consistently:
+//
+//	if err := errorhandling.Ensure(age >= 0, "age must be positive, got %d", age); err != nil {
+//		return err
+//	}
+func Ensure(cond bool, format string, args ...interface{}) error {
+	if cond {
+		return nil
+	}
+	return InvalidArgument(format, args...)
+}
+
+//EnsureNotNil returns a CodeInvalidArgument error if v is nil. Unlike v == nil, this also detects nil pointers,
+//maps, slices, channels and functions stored in the interface
+func EnsureNotNil(name string, v interface{}) error {
+	if isNil(v) {
+		return InvalidArgument("%s must not be nil", name)
+	}
+	return nil
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
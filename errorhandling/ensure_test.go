@@ -0,0 +1,26 @@
+package errorhandling_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func TestEnsure(t *testing.T) {
+	assert.Nil(t, errorhandling.Ensure(43 >= 0, "age must be positive"))
+
+	err := errorhandling.Ensure(-1 >= 0, "age must be positive, got %d", -1)
+	assert.Equal(t, "age must be positive, got -1", err.Error())
+	assert.Equal(t, errorhandling.CodeInvalidArgument, errorhandling.CodeOf(err))
+}
+
+func TestEnsureNotNil(t *testing.T) {
+	var customError *errorhandling.CustomError //A typed nil is not == nil once stored in an interface
+	err := errorhandling.EnsureNotNil("customError", customError)
+	assert.Equal(t, "customError must not be nil", err.Error())
+	assert.Equal(t, errorhandling.CodeInvalidArgument, errorhandling.CodeOf(err))
+
+	assert.Error(t, errorhandling.EnsureNotNil("value", nil))
+	assert.Nil(t, errorhandling.EnsureNotNil("value", &errorhandling.CustomError{}))
+	assert.Nil(t, errorhandling.EnsureNotNil("value", 0))
+}
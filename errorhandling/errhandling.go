@@ -2,9 +2,9 @@ package errorhandling
 
 import "fmt"
 
-var (
+const (
 	//ConnectionError is a package defined error that allows users to react to different error conditions
-	ConnectionError = fmt.Errorf("connection failed")
+	ConnectionError = Sentinel("connection failed")
 )
 
 type CustomError struct {
@@ -0,0 +1,63 @@
+package errorhandling
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+//Exit codes follow the BSD sysexits convention where applicable
+var exitCodes = map[Code]int{
+	CodeOK:               0,
+	CodeUnknown:          1,
+	CodeInvalidArgument:  64, //EX_USAGE
+	CodeNotFound:         66, //EX_NOINPUT
+	CodeConflict:         1,
+	CodePermissionDenied: 77, //EX_NOPERM
+	CodeUnauthenticated:  77, //EX_NOPERM
+	CodeUnavailable:      69, //EX_UNAVAILABLE
+	CodeDeadlineExceeded: 75, //EX_TEMPFAIL
+	CodeInternal:         70, //EX_SOFTWARE
+}
+
+var verbose = false
+
+//SetVerbose controls whether Exit prints the full error chain for debugging instead of a single user-facing line
+func SetVerbose(v bool) {
+	verbose = v
+}
+
+//ExitCode maps err to a process exit code based on its Code: 0 for nil, 1 for errors without specific code.
+//A non-nil error never exits with 0, even if it carries CodeOK
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if CodeOf(err) == CodeOK {
+		return 1
+	}
+	if code, ok := exitCodes[CodeOf(err)]; ok {
+		return code
+	}
+	return 1
+}
+
+//Report writes err to w, either as a single user-facing line or, in verbose mode, as the full chain rendered by
+//Format. It returns the exit code for err and writes nothing for nil
+func Report(w io.Writer, err error) int {
+	if err == nil {
+		return 0
+	}
+	if verbose {
+		fmt.Fprintf(w, "error (%s):\n%s\n", CodeOf(err), Format(err))
+	} else {
+		fmt.Fprintf(w, "error: %s\n", err.Error())
+	}
+	return ExitCode(err)
+}
+
+//Exit reports err on stderr and terminates the process with its exit code. Call it from main() only, libraries must
+//return errors instead
+func Exit(err error) {
+	os.Exit(Report(os.Stderr, err))
+}
@@ -0,0 +1,36 @@
+package errorhandling_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, errorhandling.ExitCode(nil))
+	assert.Equal(t, 1, errorhandling.ExitCode(errors.New("plain")))
+	assert.Equal(t, 1, errorhandling.ExitCode(errorhandling.Coded{Message: "no code set"}))
+	assert.Equal(t, 64, errorhandling.ExitCode(errorhandling.InvalidArgument("missing --name")))
+	assert.Equal(t, 69, errorhandling.ExitCode(fmt.Errorf("fetching: %w", errorhandling.Unavailable("server down"))))
+}
+
+func TestReport(t *testing.T) {
+	err := fmt.Errorf("fetching: %w", errorhandling.Unavailable("server down"))
+
+	out := bytes.Buffer{}
+	assert.Equal(t, 69, errorhandling.Report(&out, err))
+	assert.Equal(t, "error: fetching: server down\n", out.String())
+
+	errorhandling.SetVerbose(true)
+	defer errorhandling.SetVerbose(false)
+	out.Reset()
+	errorhandling.Report(&out, err)
+	assert.Equal(t, "error (unavailable):\nfetching: server down\n  caused by: server down\n", out.String())
+
+	out.Reset()
+	assert.Equal(t, 0, errorhandling.Report(&out, nil))
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,66 @@
+package errorhandling
+
+import "fmt"
+
+type fieldsError struct {
+	err    error
+	fields map[string]interface{}
+}
+
+func (e fieldsError) Error() string {
+	return e.err.Error()
+}
+
+func (e fieldsError) Unwrap() error {
+	return e.err
+}
+
+//WithFields attaches structured key/value context, like a user or request ID, to err without changing its message.
+//Loggers can extract the fields with Fields. Keys are converted to strings, a key without value gets nil. nil stays nil
+func WithFields(err error, kv ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	fields := make(map[string]interface{}, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		var value interface{}
+		if i+1 < len(kv) {
+			value = kv[i+1]
+		}
+		fields[fmt.Sprint(kv[i])] = value
+	}
+	return fieldsError{err: err, fields: fields}
+}
+
+//Fields returns all fields attached to the error chain of err. If a key is attached multiple times,
+//the outermost value wins
+func Fields(err error) map[string]interface{} {
+	fields := map[string]interface{}{}
+	walk(err, func(e error) {
+		if fe, ok := e.(fieldsError); ok {
+			for k, v := range fe.fields {
+				if _, exists := fields[k]; !exists {
+					fields[k] = v
+				}
+			}
+		}
+	})
+	return fields
+}
+
+//walk calls fn for err and every error in its chain, outermost first. Errors joining multiple errors are walked
+//depth first
+func walk(err error, fn func(error)) {
+	if err == nil {
+		return
+	}
+	fn(err)
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		walk(e.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			walk(inner, fn)
+		}
+	}
+}
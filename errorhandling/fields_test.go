@@ -0,0 +1,26 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	err := errorhandling.WithFields(errorhandling.ConnectionError, "user_id", 42, "request_id", "abc")
+	err = fmt.Errorf("loading profile: %w", err)
+	err = errorhandling.WithFields(err, "request_id", "outer", "dangling")
+
+	assert.Equal(t, "loading profile: connection failed", err.Error()) //The message is unchanged
+	assert.True(t, errors.Is(err, errorhandling.ConnectionError))
+	assert.Equal(t, map[string]interface{}{
+		"user_id":    42,
+		"request_id": "outer", //The outermost value wins
+		"dangling":   nil,
+	}, errorhandling.Fields(err))
+
+	assert.Empty(t, errorhandling.Fields(errors.New("plain")))
+	assert.Nil(t, errorhandling.WithFields(nil, "key", "value"))
+}
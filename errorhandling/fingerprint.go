@@ -0,0 +1,35 @@
+package errorhandling
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+)
+
+var (
+	//Order matters: UUIDs and hex IDs contain digits, so they must be replaced before plain numbers
+	uuidPattern   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	hexPattern    = regexp.MustCompile(`\b(0x[0-9a-fA-F]+|[0-9a-fA-F]*[0-9][0-9a-fA-F]*[a-fA-F][0-9a-fA-F]*|[0-9a-fA-F]*[a-fA-F][0-9a-fA-F]*[0-9][0-9a-fA-F]*)\b`)
+	numberPattern = regexp.MustCompile(`[0-9]+`)
+)
+
+//Fingerprint returns a stable hash identifying the kind of err, so log aggregation and alerting can group errors
+//across instances. It is based on the type of the root cause, the Code and the message with variable parts like
+//numbers, hex IDs and UUIDs stripped. "user 42 not found" and "user 7 not found" share the same fingerprint
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	chain := Chain(err)
+	root := chain[len(chain)-1]
+	h := sha256.New()
+	fmt.Fprintf(h, "%T|%s|%s", root, CodeOf(err), normalize(err.Error()))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func normalize(msg string) string {
+	msg = uuidPattern.ReplaceAllString(msg, "<uuid>")
+	msg = hexPattern.ReplaceAllString(msg, "<hex>")
+	return numberPattern.ReplaceAllString(msg, "<n>")
+}
@@ -0,0 +1,36 @@
+package errorhandling_test
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	fingerprint := func(err error) string {
+		return errorhandling.Fingerprint(fmt.Errorf("request failed: %w", err))
+	}
+
+	//Variable parts of the message don't matter
+	assert.Equal(t,
+		fingerprint(errorhandling.NotFound("user 42 not found")),
+		fingerprint(errorhandling.NotFound("user 7 not found")))
+	assert.Equal(t,
+		fingerprint(errorhandling.Internal("order 3f2a9c1b-0d4e-4b6a-9f1e-2c3d4e5f6a7b: disk 0xdeadbeef failed")),
+		fingerprint(errorhandling.Internal("order 0a1b2c3d-4e5f-4a6b-8c9d-0e1f2a3b4c5d: disk 0xcafe failed")))
+
+	//Code, type and text do
+	assert.NotEqual(t,
+		fingerprint(errorhandling.NotFound("user 42 not found")),
+		fingerprint(errorhandling.Conflict("user 42 not found")))
+	assert.NotEqual(t,
+		fingerprint(errorhandling.ConnectionError),
+		fingerprint(fmt.Errorf("connection failed")))
+	assert.NotEqual(t,
+		fingerprint(errorhandling.ConnectionError),
+		fingerprint(errorhandling.ReturnCustomError()))
+
+	assert.Len(t, errorhandling.Fingerprint(errorhandling.ConnectionError), 16)
+	assert.Equal(t, "", errorhandling.Fingerprint(nil))
+}
@@ -0,0 +1,97 @@
+package errorhandling
+
+import (
+	"context"
+	"sync"
+)
+
+//GroupMode defines how a Group reacts to errors of its go routines
+type GroupMode int
+
+const (
+	//FailFast cancels the group's context on the first error. Wait returns that error
+	FailFast GroupMode = iota
+	//CollectAll lets all go routines finish. Wait returns all errors as MultiError
+	CollectAll
+	//BestEffort lets the go routines continue until the max errors threshold is reached, then cancels the group's
+	//context. Wait returns all errors as MultiError
+	BestEffort
+)
+
+type groupConfig struct {
+	maxErrors int
+}
+
+type GroupOption func(*groupConfig)
+
+//WithMaxErrors sets the number of errors after which a BestEffort group is cancelled. Zero means no limit
+func WithMaxErrors(n int) GroupOption {
+	return func(c *groupConfig) {
+		c.maxErrors = n
+	}
+}
+
+//Group runs go routines and propagates their errors, which a plain sync.WaitGroup can't do
+type Group struct {
+	mode   GroupMode
+	config groupConfig
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	first  error
+	errs   MultiError
+}
+
+//NewGroup creates a Group. The returned context is cancelled according to mode, and always once Wait returns.
+//Pass it to the functions started with Go
+func NewGroup(ctx context.Context, mode GroupMode, opts ...GroupOption) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	g := &Group{
+		mode:   mode,
+		cancel: cancel,
+	}
+	for idx := range opts {
+		opts[idx](&g.config)
+	}
+	return g, ctx
+}
+
+//Go runs fn in a new go routine
+func (g *Group) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := fn(); err != nil {
+			g.record(err)
+		}
+	}()
+}
+
+func (g *Group) record(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.first == nil {
+		g.first = err
+	}
+	g.errs.Append(err)
+	switch g.mode {
+	case FailFast:
+		g.cancel()
+	case BestEffort:
+		if g.config.maxErrors > 0 && len(g.errs.Errors) >= g.config.maxErrors {
+			g.cancel()
+		}
+	}
+}
+
+//Wait blocks until all go routines returned and returns their error(s) according to the mode
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.mode == FailFast {
+		return g.first
+	}
+	return g.errs.ErrorOrNil()
+}
@@ -0,0 +1,59 @@
+package errorhandling_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func TestGroupFailFast(t *testing.T) {
+	g, ctx := errorhandling.NewGroup(context.Background(), errorhandling.FailFast)
+	g.Go(func() error {
+		return errorhandling.ConnectionError
+	})
+	g.Go(func() error {
+		<-ctx.Done() //Cancelled by the failing routine
+		return ctx.Err()
+	})
+	assert.Equal(t, errorhandling.ConnectionError, g.Wait())
+}
+
+func TestGroupCollectAll(t *testing.T) {
+	g, _ := errorhandling.NewGroup(context.Background(), errorhandling.CollectAll)
+	for i := 0; i < 3; i++ {
+		i := i
+		g.Go(func() error {
+			if i == 1 {
+				return nil
+			}
+			return fmt.Errorf("task %d failed", i)
+		})
+	}
+	err := g.Wait()
+	var multi *errorhandling.MultiError
+	if assert.True(t, errors.As(err, &multi)) {
+		assert.Len(t, multi.Errors, 2)
+	}
+}
+
+func TestGroupBestEffort(t *testing.T) {
+	g, ctx := errorhandling.NewGroup(context.Background(), errorhandling.BestEffort, errorhandling.WithMaxErrors(2))
+	g.Go(func() error { return errors.New("first") })
+	g.Go(func() error {
+		assert.Nil(t, ctx.Err()) //One error is below the threshold
+		return nil
+	})
+	assert.Len(t, g.Wait().(*errorhandling.MultiError).Errors, 1)
+
+	g, ctx = errorhandling.NewGroup(context.Background(), errorhandling.BestEffort, errorhandling.WithMaxErrors(2))
+	g.Go(func() error { return errors.New("first") })
+	g.Go(func() error { return errors.New("second") })
+	g.Go(func() error {
+		<-ctx.Done() //Threshold reached
+		return nil
+	})
+	assert.Error(t, g.Wait())
+}
@@ -0,0 +1,71 @@
+package errorhandling
+
+import (
+	"encoding/json"
+	"errors"
+	"minimalgo/packagelog"
+	"net/http"
+)
+
+var httpStatus = map[Code]int{
+	CodeOK:               http.StatusOK,
+	CodeUnknown:          http.StatusInternalServerError,
+	CodeInvalidArgument:  http.StatusBadRequest,
+	CodeNotFound:         http.StatusNotFound,
+	CodeConflict:         http.StatusConflict,
+	CodePermissionDenied: http.StatusForbidden,
+	CodeUnauthenticated:  http.StatusUnauthorized,
+	CodeUnavailable:      http.StatusServiceUnavailable,
+	CodeDeadlineExceeded: http.StatusGatewayTimeout,
+	CodeInternal:         http.StatusInternalServerError,
+}
+
+//HTTPStatus maps err to an HTTP status code based on its Code. ValidationErrors map to 400 Bad Request. A non-nil
+//error never maps to 200 OK, a Coded error without code is treated as CodeUnknown
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	var validation *ValidationErrors
+	if errors.As(err, &validation) {
+		return http.StatusBadRequest
+	}
+	code := CodeOf(err)
+	if code == CodeOK {
+		code = CodeUnknown
+	}
+	if status, ok := httpStatus[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+//HandlerFunc is an http handler that can return an error
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+//Handler adapts fn to an http.Handler. A returned error is logged through packagelog and answered with the status
+//code of HTTPStatus. Client errors (4xx) reveal the error message, server errors only the status text, so internals
+//don't leak. ValidationErrors are answered with their JSON representation
+func Handler(fn HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := fn(w, r)
+		if err == nil {
+			return
+		}
+		status := HTTPStatus(err)
+		packagelog.FromContext(r.Context()).Errorf("%s %s failed with status %d: %s", r.Method, r.URL.Path, status, err.Error())
+
+		var validation *ValidationErrors
+		if errors.As(err, &validation) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(validation)
+			return
+		}
+		message := http.StatusText(status)
+		if status < http.StatusInternalServerError {
+			message = err.Error()
+		}
+		http.Error(w, message, status)
+	})
+}
@@ -0,0 +1,66 @@
+package errorhandling_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	var tests = []struct {
+		Name           string
+		Input          error
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		{
+			Name:           "Success",
+			Input:          nil,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "ok",
+		},
+		{
+			Name:           "Not found",
+			Input:          errorhandling.NotFound("user 42 not found"),
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   "user 42 not found\n",
+		},
+		{
+			Name:           "Internal details are hidden",
+			Input:          errorhandling.ConnectionError,
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedBody:   "Internal Server Error\n",
+		},
+		{
+			Name:           "Coded without code",
+			Input:          errorhandling.Coded{Message: "broken"},
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedBody:   "Internal Server Error\n",
+		},
+		{
+			Name:           "Validation",
+			Input:          (&errorhandling.ValidationErrors{}).Add("name", "must not be empty"),
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   "{\"name\":[\"must not be empty\"]}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			handler := errorhandling.Handler(func(w http.ResponseWriter, r *http.Request) error {
+				if test.Input != nil {
+					return test.Input
+				}
+				w.Write([]byte("ok"))
+				return nil
+			})
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/users/42", nil))
+
+			assert.Equal(t, test.ExpectedStatus, recorder.Code)
+			assert.Equal(t, test.ExpectedBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package errorhandling
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+//jsonError is the stable wire format of an error
+type jsonError struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Causes  []jsonError            `json:"causes,omitempty"`
+}
+
+//remoteError is a reconstructed error without code that wrapped other errors
+type remoteError struct {
+	message string
+	causes  []error
+}
+
+func (e remoteError) Error() string {
+	return e.message
+}
+
+func (e remoteError) Unwrap() []error {
+	return e.causes
+}
+
+//MarshalJSON serializes err including its code, fields and the chain of wrapped causes, so it can cross process
+//boundaries. nil is serialized as null
+func MarshalJSON(err error) []byte {
+	if err == nil {
+		return []byte("null")
+	}
+	data, _ := json.Marshal(toJSONError(err)) //Cannot fail, fields that can't be serialized are dropped beforehand
+	return data
+}
+
+func toJSONError(err error) jsonError {
+	//Fields are attached to the error they wrap, not serialized as their own level
+	fields := map[string]interface{}{}
+	for {
+		fe, ok := err.(fieldsError)
+		if !ok {
+			break
+		}
+		for k, v := range fe.fields {
+			if _, exists := fields[k]; !exists {
+				if _, jsonErr := json.Marshal(v); jsonErr == nil {
+					fields[k] = v
+				}
+			}
+		}
+		err = fe.err
+	}
+
+	result := jsonError{
+		Code:    CodeUnknown.String(),
+		Message: err.Error(),
+	}
+	if len(fields) > 0 {
+		result.Fields = fields
+	}
+	if coded, ok := err.(Coded); ok {
+		result.Code = coded.Code.String()
+	}
+	var causes []error
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			causes = []error{inner}
+		}
+	case interface{ Unwrap() []error }:
+		causes = e.Unwrap()
+	}
+	for _, cause := range causes {
+		result.Causes = append(result.Causes, toJSONError(cause))
+	}
+	return result
+}
+
+//UnmarshalError reconstructs an error serialized with MarshalJSON. Errors with a code become Coded errors and errors
+//without code and causes become Sentinel errors, so the client can use CodeOf(), errors.Is() and errors.As() as if
+//the error had been created locally. null yields nil, malformed data yields a CodeInternal error wrapping the
+//decoding error
+func UnmarshalError(data []byte) error {
+	var je *jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return Internal("malformed error data: %w", err)
+	}
+	if je == nil {
+		return nil
+	}
+	return fromJSONError(*je)
+}
+
+func fromJSONError(je jsonError) error {
+	causes := make([]error, 0, len(je.Causes))
+	for _, c := range je.Causes {
+		causes = append(causes, fromJSONError(c))
+	}
+
+	var err error
+	code := codeFromString(je.Code)
+	switch {
+	case code != CodeUnknown:
+		coded := Coded{Code: code, Message: je.Message}
+		if len(causes) == 1 {
+			coded.Err = causes[0]
+		} else if len(causes) > 1 {
+			coded.Err = errors.Join(causes...)
+		}
+		err = coded
+	case len(causes) == 0:
+		err = Sentinel(je.Message)
+	default:
+		err = remoteError{message: je.Message, causes: causes}
+	}
+
+	if len(je.Fields) > 0 {
+		kv := make([]interface{}, 0, len(je.Fields)*2)
+		for k, v := range je.Fields {
+			kv = append(kv, k, v)
+		}
+		err = WithFields(err, kv...)
+	}
+	return err
+}
+
+func codeFromString(s string) Code {
+	for code, name := range codeNames {
+		if name == s {
+			return code
+		}
+	}
+	return CodeUnknown
+}
@@ -0,0 +1,43 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	original := errorhandling.WithFields(
+		errorhandling.NotFound("user %s: %w", "42", errorhandling.ConnectionError),
+		"user_id", "42")
+	original = fmt.Errorf("loading profile: %w", original)
+
+	data := errorhandling.MarshalJSON(original)
+	assert.JSONEq(t, `{
+		"code": "unknown",
+		"message": "loading profile: user 42: connection failed",
+		"causes": [{
+			"code": "not found",
+			"message": "user 42: connection failed",
+			"fields": {"user_id": "42"},
+			"causes": [{"code": "unknown", "message": "connection failed"}]
+		}]
+	}`, string(data))
+
+	//The reconstructed error can be handled like the original
+	err := errorhandling.UnmarshalError(data)
+	assert.Equal(t, original.Error(), err.Error())
+	assert.Equal(t, errorhandling.CodeNotFound, errorhandling.CodeOf(err))
+	assert.True(t, errors.Is(err, errorhandling.ConnectionError))
+	assert.Equal(t, map[string]interface{}{"user_id": "42"}, errorhandling.Fields(err))
+}
+
+func TestJSONNil(t *testing.T) {
+	assert.Equal(t, "null", string(errorhandling.MarshalJSON(nil)))
+	assert.Nil(t, errorhandling.UnmarshalError([]byte("null")))
+
+	err := errorhandling.UnmarshalError([]byte("{"))
+	assert.Equal(t, errorhandling.CodeInternal, errorhandling.CodeOf(err))
+}
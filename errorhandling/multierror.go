@@ -0,0 +1,57 @@
+package errorhandling
+
+import (
+	"fmt"
+	"strings"
+)
+
+//MultiError collects multiple errors, e.g. when validating several fields or joining the results of go routines.
+//errors.Is() and errors.As() examine all contained errors
+type MultiError struct {
+	Errors []error
+}
+
+//Append adds the given errors, nil errors are skipped. Appending another MultiError adds its errors individually
+func (m *MultiError) Append(errs ...error) *MultiError {
+	for _, err := range errs {
+		switch e := err.(type) {
+		case nil:
+		case *MultiError:
+			if e != nil {
+				m.Errors = append(m.Errors, e.Errors...)
+			}
+		default:
+			m.Errors = append(m.Errors, err)
+		}
+	}
+	return m
+}
+
+//ErrorOrNil returns nil if no errors were collected, otherwise the MultiError itself.
+//Always return this instead of the *MultiError to avoid a non-nil error interface holding a nil pointer
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.Errors) == 0 {
+		return nil
+	}
+	return m
+}
+
+//Error satisfies the error interface
+func (m *MultiError) Error() string {
+	switch len(m.Errors) {
+	case 0:
+		return "no errors"
+	case 1:
+		return m.Errors[0].Error()
+	}
+	messages := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(m.Errors), strings.Join(messages, "; "))
+}
+
+//Unwrap makes the contained errors available to errors.Is() and errors.As()
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
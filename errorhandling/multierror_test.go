@@ -0,0 +1,34 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	var errs errorhandling.MultiError
+	assert.Nil(t, errs.ErrorOrNil())
+
+	errs.Append(nil, errorhandling.ReturnPredefinedError())
+	assert.Equal(t, "connection failed", errs.Error())
+
+	errs.Append(fmt.Errorf("validating: %w", errorhandling.ReturnCustomError()))
+	err := errs.ErrorOrNil()
+	assert.Equal(t, "2 errors occurred: connection failed; validating: failed with status 22: Just cause", err.Error())
+
+	//errors.Is() and errors.As() examine all contained errors
+	assert.True(t, errors.Is(err, errorhandling.ConnectionError))
+	var ce errorhandling.CustomError
+	if assert.True(t, errors.As(err, &ce)) {
+		assert.Equal(t, 22, ce.Status)
+	}
+}
+
+func TestMultiErrorFlatten(t *testing.T) {
+	inner := (&errorhandling.MultiError{}).Append(errors.New("a"), errors.New("b"))
+	outer := (&errorhandling.MultiError{}).Append(inner, errors.New("c"))
+	assert.Len(t, outer.Errors, 3)
+}
@@ -0,0 +1,56 @@
+package errorhandling
+
+import (
+	"errors"
+	"net"
+)
+
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e retryableError) Unwrap() error {
+	return e.err
+}
+
+func (e retryableError) Retryable() bool {
+	return true
+}
+
+//MarkRetryable wraps err, so Retryable reports true for it. The error message is unchanged. nil stays nil
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return retryableError{err: err}
+}
+
+//Retryable reports whether retrying the operation that caused err may succeed. It examines the entire error chain
+//for errors marked with MarkRetryable, net.Error timeouts, errors implementing Temporary() bool and Coded errors
+//with CodeUnavailable or CodeDeadlineExceeded
+func Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var r interface{ Retryable() bool }
+	if errors.As(err, &r) {
+		return r.Retryable()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+	switch CodeOf(err) {
+	case CodeUnavailable, CodeDeadlineExceeded:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"net"
+	"testing"
+)
+
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "try later" }
+func (temporaryError) Temporary() bool { return true }
+
+func TestRetryable(t *testing.T) {
+	var tests = []struct {
+		Name           string
+		Input          error
+		ExpectedOutput bool
+	}{
+		{
+			Name:           "Nil",
+			Input:          nil,
+			ExpectedOutput: false,
+		},
+		{
+			Name:           "Plain",
+			Input:          errorhandling.ConnectionError,
+			ExpectedOutput: false,
+		},
+		{
+			Name:           "Marked",
+			Input:          fmt.Errorf("wrapped: %w", errorhandling.MarkRetryable(errorhandling.ConnectionError)),
+			ExpectedOutput: true,
+		},
+		{
+			Name:           "Net timeout",
+			Input:          fmt.Errorf("dial: %w", &net.DNSError{Err: "timeout", IsTimeout: true}),
+			ExpectedOutput: true,
+		},
+		{
+			Name:           "Temporary",
+			Input:          temporaryError{},
+			ExpectedOutput: true,
+		},
+		{
+			Name:           "Unavailable",
+			Input:          errorhandling.Unavailable("service down"),
+			ExpectedOutput: true,
+		},
+		{
+			Name:           "Not found",
+			Input:          errorhandling.NotFound("user"),
+			ExpectedOutput: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.ExpectedOutput, errorhandling.Retryable(test.Input))
+		})
+	}
+}
+
+func TestMarkRetryable(t *testing.T) {
+	err := errorhandling.MarkRetryable(errorhandling.ConnectionError)
+	assert.Equal(t, "connection failed", err.Error())
+	assert.True(t, errors.Is(err, errorhandling.ConnectionError))
+	assert.Nil(t, errorhandling.MarkRetryable(nil))
+}
@@ -0,0 +1,12 @@
+package errorhandling
+
+//Sentinel is a string based error type that allows package defined errors to be declared as constants.
+//Unlike an error created with fmt.Errorf() and stored in a package var, a constant cannot be reassigned by callers
+//
+//	const ErrConnection = Sentinel("connection failed")
+type Sentinel string
+
+//Error satisfies the error interface
+func (s Sentinel) Error() string {
+	return string(s)
+}
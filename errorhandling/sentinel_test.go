@@ -0,0 +1,22 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+const errNotReady = errorhandling.Sentinel("not ready")
+
+func TestSentinel(t *testing.T) {
+	var err error = errNotReady
+	assert.Equal(t, "not ready", err.Error())
+	assert.True(t, err == errNotReady)
+
+	//Sentinels are comparable values, so errors.Is() finds them in the chain
+	wrapped := fmt.Errorf("starting: %w", err)
+	assert.True(t, errors.Is(wrapped, errNotReady))
+	assert.False(t, errors.Is(wrapped, errorhandling.ConnectionError))
+}
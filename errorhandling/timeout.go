@@ -0,0 +1,70 @@
+package errorhandling
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"time"
+)
+
+//Interruption distinguishes why an operation did not complete. This drives retry decisions: retrying makes no sense
+//if the caller cancelled, but might help after a downstream timeout
+type Interruption int
+
+const (
+	//InterruptionNone means the error is not caused by cancellation or a timeout
+	InterruptionNone Interruption = iota
+	//InterruptionCanceled means the caller cancelled the operation
+	InterruptionCanceled
+	//InterruptionDeadline means the deadline of the caller's context expired
+	InterruptionDeadline
+	//InterruptionDownstreamTimeout means a dependency timed out while the caller's context was still alive
+	InterruptionDownstreamTimeout
+)
+
+//IsTimeout reports whether the chain of err contains a timeout: an expired context deadline, an os deadline,
+//a net.Error timeout or a Coded error with CodeDeadlineExceeded
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return CodeOf(err) == CodeDeadlineExceeded
+}
+
+//InterruptionOf classifies err, which was returned by an operation running with ctx
+func InterruptionOf(ctx context.Context, err error) Interruption {
+	if err == nil {
+		return InterruptionNone
+	}
+	//The state of our own context tells who gave up first
+	switch ctx.Err() {
+	case context.Canceled:
+		return InterruptionCanceled
+	case context.DeadlineExceeded:
+		return InterruptionDeadline
+	}
+	if IsTimeout(err) {
+		return InterruptionDownstreamTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return InterruptionCanceled
+	}
+	return InterruptionNone
+}
+
+//WithTimeoutCause returns a context that expires after d. When it expires, context.Cause() returns cause wrapped
+//into a CodeDeadlineExceeded error, while ctx.Err() still returns context.DeadlineExceeded. cause may be nil
+func WithTimeoutCause(ctx context.Context, d time.Duration, cause error) (context.Context, context.CancelFunc) {
+	if cause == nil {
+		return context.WithTimeoutCause(ctx, d, DeadlineExceeded("timed out after %s", d))
+	}
+	return context.WithTimeoutCause(ctx, d, DeadlineExceeded("timed out after %s: %w", d, cause))
+}
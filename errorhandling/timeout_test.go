@@ -0,0 +1,58 @@
+package errorhandling_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestIsTimeout(t *testing.T) {
+	assert.True(t, errorhandling.IsTimeout(fmt.Errorf("query: %w", context.DeadlineExceeded)))
+	assert.True(t, errorhandling.IsTimeout(&net.DNSError{IsTimeout: true}))
+	assert.True(t, errorhandling.IsTimeout(errorhandling.DeadlineExceeded("too slow")))
+	assert.False(t, errorhandling.IsTimeout(context.Canceled))
+	assert.False(t, errorhandling.IsTimeout(nil))
+}
+
+func TestInterruptionOf(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, errorhandling.InterruptionCanceled, errorhandling.InterruptionOf(cancelled, cancelled.Err()))
+
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-expired.Done()
+	assert.Equal(t, errorhandling.InterruptionDeadline, errorhandling.InterruptionOf(expired, expired.Err()))
+
+	//Our context is fine, but the HTTP call to a dependency timed out
+	downstream := fmt.Errorf("calling billing: %w", &net.DNSError{IsTimeout: true})
+	assert.Equal(t, errorhandling.InterruptionDownstreamTimeout, errorhandling.InterruptionOf(context.Background(), downstream))
+
+	assert.Equal(t, errorhandling.InterruptionNone, errorhandling.InterruptionOf(context.Background(), errorhandling.ConnectionError))
+}
+
+func TestWithTimeoutCause(t *testing.T) {
+	ctx, cancel := errorhandling.WithTimeoutCause(context.Background(), time.Millisecond, errorhandling.ConnectionError)
+	defer cancel()
+	<-ctx.Done()
+
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+	cause := context.Cause(ctx)
+	assert.True(t, errors.Is(cause, errorhandling.ConnectionError))
+	assert.True(t, errorhandling.IsTimeout(cause))
+}
+
+func TestWithTimeoutCauseNil(t *testing.T) {
+	ctx, cancel := errorhandling.WithTimeoutCause(context.Background(), time.Millisecond, nil)
+	defer cancel()
+	<-ctx.Done()
+
+	cause := context.Cause(ctx)
+	assert.EqualError(t, cause, "timed out after 1ms")
+	assert.True(t, errorhandling.IsTimeout(cause))
+}
@@ -0,0 +1,39 @@
+package errorhandling
+
+import "sync"
+
+//TranslateFunc translates err into a package error and returns true, or returns false if it doesn't handle err
+type TranslateFunc func(err error) (error, bool)
+
+//Translator converts third-party errors, e.g. from a database driver, into package errors at repository boundaries,
+//so callers never depend on the driver's error types
+type Translator struct {
+	mu          sync.RWMutex
+	translators []TranslateFunc
+}
+
+func NewTranslator() *Translator {
+	return &Translator{}
+}
+
+//Register adds fn. Translators are tried in registration order, the first one handling an error wins
+func (t *Translator) Register(fn TranslateFunc) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.translators = append(t.translators, fn)
+}
+
+//Translate returns the translation of err, or err itself if no translator handles it. nil stays nil
+func (t *Translator) Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, fn := range t.translators {
+		if translated, ok := fn(err); ok {
+			return translated
+		}
+	}
+	return err
+}
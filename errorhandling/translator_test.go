@@ -0,0 +1,47 @@
+package errorhandling_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+//uniqueViolation mimics a driver specific error type
+type uniqueViolation struct {
+	Constraint string
+}
+
+func (e *uniqueViolation) Error() string {
+	return "duplicate key value violates unique constraint " + e.Constraint
+}
+
+func TestTranslator(t *testing.T) {
+	translator := errorhandling.NewTranslator()
+	translator.Register(func(err error) (error, bool) {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhandling.NotFound("user not found: %w", err), true
+		}
+		return nil, false
+	})
+	translator.Register(func(err error) (error, bool) {
+		var uv *uniqueViolation
+		if errors.As(err, &uv) {
+			return errorhandling.Conflict("user already exists"), true
+		}
+		return nil, false
+	})
+
+	err := translator.Translate(fmt.Errorf("select user: %w", sql.ErrNoRows))
+	assert.Equal(t, errorhandling.CodeNotFound, errorhandling.CodeOf(err))
+	assert.True(t, errors.Is(err, sql.ErrNoRows)) //The translation kept the cause
+
+	err = translator.Translate(&uniqueViolation{Constraint: "users_email_key"})
+	assert.Equal(t, errorhandling.CodeConflict, errorhandling.CodeOf(err))
+
+	//Unhandled errors pass through unchanged
+	assert.Equal(t, errorhandling.ConnectionError, translator.Translate(errorhandling.ConnectionError))
+	assert.Nil(t, translator.Translate(nil))
+}
@@ -0,0 +1,67 @@
+package errorhandling
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//ValidationErrors collects validation failures by field name. The zero value is ready to use
+type ValidationErrors struct {
+	fields map[string][]string
+}
+
+//Add records msg as a validation failure of field. msg is formatted like fmt.Sprintf only if args are given, so
+//messages containing user input with '%' are kept as they are
+func (v *ValidationErrors) Add(field, msg string, args ...interface{}) *ValidationErrors {
+	if v.fields == nil {
+		v.fields = map[string][]string{}
+	}
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	v.fields[field] = append(v.fields[field], msg)
+	return v
+}
+
+//Field returns the messages recorded for field
+func (v *ValidationErrors) Field(field string) []string {
+	return v.fields[field]
+}
+
+//Fields returns the names of all invalid fields in alphabetical order
+func (v *ValidationErrors) Fields() []string {
+	names := make([]string, 0, len(v.fields))
+	for name := range v.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//ErrorOrNil returns nil if no failures were recorded, otherwise the ValidationErrors itself
+func (v *ValidationErrors) ErrorOrNil() error {
+	if v == nil || len(v.fields) == 0 {
+		return nil
+	}
+	return v
+}
+
+//Error satisfies the error interface, summarizing all fields in alphabetical order
+func (v *ValidationErrors) Error() string {
+	parts := make([]string, 0, len(v.fields))
+	for _, name := range v.Fields() {
+		parts = append(parts, fmt.Sprintf("%s: %s", name, strings.Join(v.fields[name], ", ")))
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+//MarshalJSON renders the failures as object of field name to messages, suitable for API responses:
+//{"name":["must not be empty"],"age":["must be positive"]}
+func (v *ValidationErrors) MarshalJSON() ([]byte, error) {
+	if v == nil || v.fields == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(v.fields)
+}
@@ -0,0 +1,54 @@
+package errorhandling_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+func validate(name string, age int) error {
+	var errs errorhandling.ValidationErrors
+	if name == "" {
+		errs.Add("name", "must not be empty")
+	}
+	if age < 0 {
+		errs.Add("age", "must be positive, got %d", age)
+	}
+	if age > 150 {
+		errs.Add("age", "must be realistic")
+	}
+	return errs.ErrorOrNil()
+}
+
+func TestValidationErrors(t *testing.T) {
+	assert.Nil(t, validate("Paul", 43))
+
+	err := validate("", -1)
+	assert.Equal(t, "validation failed: age: must be positive, got -1; name: must not be empty", err.Error())
+
+	var errs *errorhandling.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		assert.Equal(t, []string{"age", "name"}, errs.Fields())
+		assert.Equal(t, []string{"must not be empty"}, errs.Field("name"))
+		assert.Nil(t, errs.Field("email"))
+	}
+
+	data, jsonErr := json.Marshal(err)
+	assert.Nil(t, jsonErr)
+	assert.JSONEq(t, `{"age":["must be positive, got -1"],"name":["must not be empty"]}`, string(data))
+}
+
+func TestValidationErrorsPercent(t *testing.T) {
+	var errs errorhandling.ValidationErrors
+	errs.Add("discount", "invalid value 100%")
+	assert.Equal(t, []string{"invalid value 100%"}, errs.Field("discount"))
+}
+
+func TestValidationErrorsNilJSON(t *testing.T) {
+	var errs *errorhandling.ValidationErrors
+	data, err := errs.MarshalJSON()
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", string(data))
+}
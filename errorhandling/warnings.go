@@ -0,0 +1,61 @@
+package errorhandling
+
+import (
+	"fmt"
+	"minimalgo/packagelog"
+	"sync"
+)
+
+//Warnings collects non-fatal problems of an operation that succeeded with caveats, e.g. skipped records of an import.
+//Return it alongside the regular error instead of dropping the problems or turning them into a failure.
+//The zero value is ready to use and safe for concurrent use
+type Warnings struct {
+	mu       sync.Mutex
+	warnings []error
+}
+
+//Add records err as warning, nil is skipped
+func (w *Warnings) Add(err error) {
+	if err == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = append(w.warnings, err)
+}
+
+//Addf records a warning formatted like fmt.Errorf()
+func (w *Warnings) Addf(format string, args ...interface{}) {
+	w.Add(fmt.Errorf(format, args...))
+}
+
+//Merge adds all warnings of other, e.g. those of a sub operation
+func (w *Warnings) Merge(other *Warnings) {
+	if other == nil || other == w {
+		return
+	}
+	for _, err := range other.List() {
+		w.Add(err)
+	}
+}
+
+//List returns a copy of the recorded warnings
+func (w *Warnings) List() []error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]error(nil), w.warnings...)
+}
+
+//Len returns the number of recorded warnings
+func (w *Warnings) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.warnings)
+}
+
+//Log logs every warning at warn level through the errorhandling logger of packagelog
+func (w *Warnings) Log() {
+	for _, err := range w.List() {
+		packagelog.Named("errorhandling").Warnf("%s", err.Error())
+	}
+}
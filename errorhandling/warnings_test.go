@@ -0,0 +1,36 @@
+package errorhandling_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"testing"
+)
+
+//importRecords imports the valid records and reports the invalid ones as warnings
+func importRecords(records []string) (int, *errorhandling.Warnings, error) {
+	warnings := &errorhandling.Warnings{}
+	imported := 0
+	for i, record := range records {
+		if record == "" {
+			warnings.Addf("record %d skipped: empty", i)
+			continue
+		}
+		imported++
+	}
+	return imported, warnings, nil
+}
+
+func TestWarnings(t *testing.T) {
+	imported, warnings, err := importRecords([]string{"a", "", "c", ""})
+	assert.Nil(t, err) //The operation succeeded
+	assert.Equal(t, 2, imported)
+	assert.Equal(t, 2, warnings.Len())
+
+	all := &errorhandling.Warnings{}
+	all.Add(nil)
+	all.Merge(warnings)
+	all.Add(errorhandling.ConnectionError)
+	assert.Len(t, all.List(), 3)
+	assert.Equal(t, "record 1 skipped: empty", all.List()[0].Error())
+	all.Log()
+}
@@ -0,0 +1,52 @@
+package errorhandling
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+type wrappedError struct {
+	msg string
+	err error
+}
+
+func (e wrappedError) Error() string {
+	return e.msg
+}
+
+func (e wrappedError) Unwrap() error {
+	return e.err
+}
+
+//Wrapf wraps err like fmt.Errorf("...: %w", err) and prefixes the message with the calling function and its file
+//and line. This makes error chains traceable without the overhead of capturing full stack traces:
+//
+//	main.loadUser (main.go:42): loading user 7: connection failed
+//
+//nil stays nil
+func Wrapf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return wrappedError{
+		msg: fmt.Sprintf("%s: %s: %s", caller(2), fmt.Sprintf(format, args...), err.Error()),
+		err: err,
+	}
+}
+
+//caller describes the function skip frames up the stack as "package.Function (file.go:line)"
+func caller(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	name := "unknown"
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		name = fn.Name()
+		//Strip the import path, keep package.Function
+		name = name[strings.LastIndex(name, "/")+1:]
+	}
+	return fmt.Sprintf("%s (%s:%d)", name, filepath.Base(file), line)
+}
@@ -0,0 +1,20 @@
+package errorhandling_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/errorhandling"
+	"regexp"
+	"testing"
+)
+
+func loadUser(id int) error {
+	return errorhandling.Wrapf(errorhandling.ConnectionError, "loading user %d", id)
+}
+
+func TestWrapf(t *testing.T) {
+	err := loadUser(7)
+	assert.Regexp(t, regexp.MustCompile(`^errorhandling_test\.loadUser \(wrapf_test\.go:\d+\): loading user 7: connection failed$`), err.Error())
+	assert.True(t, errors.Is(err, errorhandling.ConnectionError))
+	assert.Nil(t, errorhandling.Wrapf(nil, "nothing to wrap"))
+}
@@ -0,0 +1,260 @@
+package mocking
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//DefaultTimeout is the request timeout of a Client created without WithTimeout or WithHTTPClient
+const DefaultTimeout = 10 * time.Second
+
+//Client is a small HTTP client for JSON APIs. Unlike http.DefaultClient, it always has a timeout
+type Client struct {
+	http     *http.Client
+	timeout  time.Duration
+	baseURL  string
+	header   http.Header
+	retries  int
+	backoff  time.Duration
+	retryAll bool
+	tokens   TokenSource
+}
+
+type ClientOption func(*Client)
+
+//WithTimeout sets the timeout of each request attempt
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+//WithBaseURL prefixes all request paths with baseURL, e.g. http://localhost:8080/api
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+//WithHeader adds a header sent with every request, e.g. Authorization
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+//WithRetry retries failed requests up to retries times. A request failed if it returned a transport error, including
+//an attempt timing out after WithTimeout, 429 or a 5xx status. Cancellation of the request's context is not retried.
+//The wait between attempts starts at backoff and doubles after every attempt. Only idempotent methods are retried,
+//as a failed POST may still have been processed, see WithRetryAllMethods
+func WithRetry(retries int, backoff time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+//WithRetryAllMethods retries POST and PATCH requests as well, for APIs that deduplicate them, e.g. by an
+//idempotency key
+func WithRetryAllMethods() ClientOption {
+	return func(c *Client) {
+		c.retryAll = true
+	}
+}
+
+//WithHTTPClient sends requests using client, e.g. one with a custom or mocked Transport. The client's own timeout
+//applies unless WithTimeout is given as well
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.http = client
+	}
+}
+
+//WithTokenSource authenticates every request attempt with a token from tokens. Tokens are fetched per attempt, so
+//an expired token is refreshed before a retry
+func WithTokenSource(tokens TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokens = tokens
+	}
+}
+
+//NewClient creates a Client
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{header: http.Header{}}
+	for idx := range opts {
+		opts[idx](c)
+	}
+	if c.http == nil {
+		c.http = &http.Client{}
+		if c.timeout == 0 {
+			c.timeout = DefaultTimeout
+		}
+	}
+	return c
+}
+
+//StatusError is returned for responses with a non 2xx status code
+type StatusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected response code: %d", e.StatusCode)
+}
+
+//DoGET sends a GET request and returns the response body
+func (c *Client) DoGET(ctx context.Context, path string) ([]byte, error) {
+	return c.Do(ctx, http.MethodGet, path, nil)
+}
+
+//DoPOST sends a POST request with body and returns the response body
+func (c *Client) DoPOST(ctx context.Context, path string, body []byte) ([]byte, error) {
+	return c.Do(ctx, http.MethodPost, path, body)
+}
+
+//DoPUT sends a PUT request with body and returns the response body
+func (c *Client) DoPUT(ctx context.Context, path string, body []byte) ([]byte, error) {
+	return c.Do(ctx, http.MethodPut, path, body)
+}
+
+//DoDELETE sends a DELETE request and returns the response body
+func (c *Client) DoDELETE(ctx context.Context, path string) ([]byte, error) {
+	return c.Do(ctx, http.MethodDelete, path, nil)
+}
+
+//GetJSON sends a GET request and decodes the JSON response into out
+func (c *Client) GetJSON(ctx context.Context, path string, out interface{}) error {
+	return c.doJSON(ctx, http.MethodGet, path, nil, out)
+}
+
+//PostJSON sends in encoded as JSON and decodes the JSON response into out. out may be nil to discard the response
+func (c *Client) PostJSON(ctx context.Context, path string, in, out interface{}) error {
+	return c.doJSON(ctx, http.MethodPost, path, in, out)
+}
+
+//PutJSON sends in encoded as JSON and decodes the JSON response into out. out may be nil to discard the response
+func (c *Client) PutJSON(ctx context.Context, path string, in, out interface{}) error {
+	return c.doJSON(ctx, http.MethodPut, path, in, out)
+}
+
+//Do sends a request, retrying according to the retry policy, and returns the response body. Non 2xx responses
+//return a *StatusError. If ctx is done while waiting to retry, the error wraps ctx.Err()
+func (c *Client) Do(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		respBody, err := c.attempt(ctx, method, path, body)
+		if err == nil || attempt >= c.retries || !c.retryable(ctx, method, err) {
+			return respBody, err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w, last attempt: %v", ctx.Err(), err)
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.header {
+		request.Header[key] = append([]string(nil), values...)
+	}
+	if c.tokens != nil {
+		token, err := c.tokens.Token()
+		if err != nil {
+			return nil, fmt.Errorf("fetching token: %w", err)
+		}
+		request.Header.Set("Authorization", token.TokenType+" "+token.AccessToken)
+	}
+	resp, err := c.http.Do(request)
+	if err != nil {
+		return nil, transportError{err: err}
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, transportError{err: err}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return respBody, &StatusError{StatusCode: resp.StatusCode, Body: respBody}
+	}
+	return respBody, nil
+}
+
+func (c *Client) doJSON(ctx context.Context, method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	respBody, err := c.Do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+//transportError marks errors of sending a request or reading its response, as opposed to errors preparing it
+type transportError struct {
+	err error
+}
+
+func (e transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e transportError) Unwrap() error {
+	return e.err
+}
+
+//idempotentMethods may be sent again without changing the result, see RFC 9110 section 9.2.2
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+//retryable returns true for transport errors and status codes indicating a temporary problem, unless ctx is done
+//or method must not be retried
+func (c *Client) retryable(ctx context.Context, method string, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || (!c.retryAll && !idempotentMethods[method]) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	var transportErr transportError
+	return errors.As(err, &transportErr)
+}
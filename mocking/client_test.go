@@ -0,0 +1,113 @@
+package mocking_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/api/users/1").Reply(200, []byte(`{"name":"Paul"}`))
+	transport.On("PUT", "/api/users/1").ExpectBody([]byte(`{"name":"Jill"}`)).Reply(204, nil)
+	transport.On("DELETE", "/api/users/1").Reply(404, []byte("not found"))
+	client := mocking.NewClient(
+		mocking.WithHTTPClient(&http.Client{Transport: transport}),
+		mocking.WithBaseURL("http://users.example/api/"),
+		mocking.WithHeader("Authorization", "Bearer token"),
+	)
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	var paul user
+	assert.Nil(t, client.GetJSON(context.Background(), "/users/1", &paul))
+	assert.Equal(t, "Paul", paul.Name)
+	assert.Nil(t, client.PutJSON(context.Background(), "/users/1", user{Name: "Jill"}, nil))
+
+	_, err := client.DoDELETE(context.Background(), "/users/1")
+	var statusErr *mocking.StatusError
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+		assert.Equal(t, []byte("not found"), statusErr.Body)
+	}
+	assert.Equal(t, "Bearer token", transport.Requests()[0].Header.Get("Authorization"))
+	assert.Nil(t, transport.Close())
+}
+
+func TestClientRetry(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/jobs").ReplyOnce(503, nil).ReplyOnce(500, nil).Reply(200, []byte("done"))
+	transport.On("GET", "/invalid").Reply(400, nil)
+	client := mocking.NewClient(
+		mocking.WithHTTPClient(&http.Client{Transport: transport}),
+		mocking.WithRetry(2, time.Millisecond),
+	)
+
+	body, err := client.DoGET(context.Background(), "http://example/jobs")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("done"), body)
+	transport.AssertCalled(t, "GET", "/jobs", 3)
+
+	//Client errors are not retried
+	_, err = client.DoGET(context.Background(), "http://example/invalid")
+	assert.EqualError(t, err, "unexpected response code: 400")
+	transport.AssertCalled(t, "GET", "/invalid", 1)
+}
+
+func TestClientRetryMethods(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("POST", "/jobs").ReplyOnce(503, nil).Reply(201, []byte("created"))
+	client := mocking.NewClient(
+		mocking.WithHTTPClient(&http.Client{Transport: transport}),
+		mocking.WithRetry(2, time.Millisecond),
+	)
+
+	//The failed POST may have been processed, sending it again could create a duplicate
+	_, err := client.DoPOST(context.Background(), "http://example/jobs", []byte("job"))
+	assert.EqualError(t, err, "unexpected response code: 503")
+	transport.AssertCalled(t, "POST", "/jobs", 1)
+
+	transport.On("POST", "/orders").ReplyOnce(503, nil).Reply(201, []byte("created"))
+	client = mocking.NewClient(
+		mocking.WithHTTPClient(&http.Client{Transport: transport}),
+		mocking.WithRetry(2, time.Millisecond),
+		mocking.WithRetryAllMethods(),
+	)
+	body, err := client.DoPOST(context.Background(), "http://example/orders", []byte("order"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("created"), body)
+	transport.AssertCalled(t, "POST", "/orders", 2)
+}
+
+func TestClientRetryCancel(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/jobs").Reply(503, nil)
+	client := mocking.NewClient(
+		mocking.WithHTTPClient(&http.Client{Transport: transport}),
+		mocking.WithRetry(2, time.Minute),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.DoGET(ctx, "http://example/jobs")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualError(t, err, "context deadline exceeded, last attempt: unexpected response code: 503")
+	transport.AssertCalled(t, "GET", "/jobs", 1)
+}
+
+func TestClientTimeout(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/slow").With(mocking.WithDelay(time.Second))
+	client := mocking.NewClient(
+		mocking.WithHTTPClient(&http.Client{Transport: transport}),
+		mocking.WithTimeout(10*time.Millisecond),
+	)
+
+	_, err := client.DoGET(context.Background(), "http://example/slow")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
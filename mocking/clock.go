@@ -0,0 +1,211 @@
+package mocking
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+//Clock abstracts time, so code depending on it can be tested with a FakeClock instead of sleeping for real
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+	Sleep(d time.Duration)
+}
+
+//Timer abstracts time.Timer
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+//Ticker abstracts time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+//RealClock returns a Clock backed by the time package, for production code
+func RealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+//FakeClock is a Clock that only moves when Advance is called. Timers, tickers and sleeps fire as soon as the clock
+//is advanced past their deadline:
+//
+//	clock := mocking.NewFakeClock(time.Now())
+//	go worker(clock)        //Calls clock.Sleep(time.Minute)
+//	clock.BlockUntil(1)     //Wait for the worker to sleep
+//	clock.Advance(time.Minute)
+type FakeClock struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+//fakeWaiter is a pending timer, ticker or sleep
+type fakeWaiter struct {
+	clock    *FakeClock
+	deadline time.Time
+	period   time.Duration //Only set for tickers
+	c        chan time.Time
+}
+
+//NewFakeClock returns a FakeClock set to now
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+//Now returns the fake time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+//After returns a channel receiving the fake time once the clock was advanced by d
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+//Sleep blocks until the clock was advanced by d
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+//NewTimer returns a Timer firing once the clock was advanced by d
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &fakeWaiter{clock: c, c: make(chan time.Time, 1)}
+	c.schedule(w, d)
+	return (*fakeTimer)(w)
+}
+
+//NewTicker returns a Ticker firing every time the clock was advanced by d. Like time.Ticker, it drops ticks the
+//receiver is not ready for
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("mocking: non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &fakeWaiter{clock: c, period: d, c: make(chan time.Time, 1)}
+	c.schedule(w, d)
+	return (*fakeTicker)(w)
+}
+
+//Advance moves the clock forward by d and fires all timers, tickers and sleeps due until then, in deadline order
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	target := c.now.Add(d)
+	for len(c.waiters) > 0 && !c.waiters[0].deadline.After(target) {
+		w := c.waiters[0]
+		c.now = w.deadline
+		c.unschedule(w)
+		select {
+		case w.c <- c.now:
+		default: //Receiver is not ready, drop the tick
+		}
+		if w.period > 0 {
+			c.schedule(w, w.period)
+		}
+	}
+	c.now = target
+}
+
+//BlockUntil blocks until at least n timers, tickers or sleeps are pending. Use it to wait for the code under test
+//to reach its wait before calling Advance
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.changed.Wait()
+	}
+}
+
+//schedule adds w to the sorted waiters. Must be called with the lock held
+func (c *FakeClock) schedule(w *fakeWaiter, d time.Duration) {
+	w.deadline = c.now.Add(d)
+	idx := sort.Search(len(c.waiters), func(i int) bool { return c.waiters[i].deadline.After(w.deadline) })
+	c.waiters = append(c.waiters, nil)
+	copy(c.waiters[idx+1:], c.waiters[idx:])
+	c.waiters[idx] = w
+	c.changed.Broadcast()
+}
+
+//unschedule removes w and returns true if it was pending. Must be called with the lock held
+func (c *FakeClock) unschedule(w *fakeWaiter) bool {
+	for idx := range c.waiters {
+		if c.waiters[idx] == w {
+			c.waiters = append(c.waiters[:idx], c.waiters[idx+1:]...)
+			c.changed.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer fakeWaiter
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.unschedule((*fakeWaiter)(t))
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.clock.unschedule((*fakeWaiter)(t))
+	t.clock.schedule((*fakeWaiter)(t), d)
+	return active
+}
+
+type fakeTicker fakeWaiter
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.clock.unschedule((*fakeWaiter)(t))
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.clock.unschedule((*fakeWaiter)(t))
+	t.period = d
+	t.clock.schedule((*fakeWaiter)(t), d)
+}
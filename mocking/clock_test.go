@@ -0,0 +1,63 @@
+package mocking_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"testing"
+	"time"
+)
+
+var clockStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFakeClockTimers(t *testing.T) {
+	clock := mocking.NewFakeClock(clockStart)
+	timer := clock.NewTimer(time.Minute)
+	stopped := clock.NewTimer(time.Second)
+	assert.True(t, stopped.Stop())
+
+	clock.Advance(59 * time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	assert.Equal(t, clockStart.Add(time.Minute), <-timer.C())
+	assert.Equal(t, clockStart.Add(time.Minute), clock.Now())
+	assert.False(t, timer.Stop()) //Already fired
+	assert.Len(t, stopped.C(), 0)
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	clock := mocking.NewFakeClock(clockStart)
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var ticks []time.Time
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+		ticks = append(ticks, <-ticker.C())
+	}
+	assert.Equal(t, []time.Time{clockStart.Add(time.Second), clockStart.Add(2 * time.Second), clockStart.Add(3 * time.Second)}, ticks)
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	clock := mocking.NewFakeClock(clockStart)
+	done := make(chan time.Time)
+	go func() {
+		clock.Sleep(time.Hour) //Returns without waiting an hour
+		done <- clock.Now()
+	}()
+
+	clock.BlockUntil(1) //Wait for the routine to sleep, otherwise Advance may run first
+	clock.Advance(time.Hour)
+	assert.Equal(t, clockStart.Add(time.Hour), <-done)
+}
+
+func TestRealClock(t *testing.T) {
+	var clock mocking.Clock = mocking.RealClock()
+	before := time.Now()
+	<-clock.NewTimer(time.Millisecond).C()
+	assert.GreaterOrEqual(t, clock.Now().Sub(before), time.Millisecond)
+}
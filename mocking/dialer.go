@@ -0,0 +1,156 @@
+package mocking
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+)
+
+//Dialer is a scripted replacement for net.Dialer, to test connection retry and failover logic without network.
+//Inject its DialContext wherever a dial function is accepted, e.g. http.Transport.DialContext:
+//
+//	dialer := mocking.NewDialer()
+//	dialer.On("primary:5432").Refuse()
+//	dialer.On("replica:5432").Timeout().Accept(handler)
+//
+//Addresses without script refuse connections
+type Dialer struct {
+	mu      sync.Mutex
+	scripts map[string]*DialScript
+	hosts   map[string][]string
+	dials   []string
+}
+
+//DialScript is the sequence of results for dials to one address. Each step is used once, the last step repeats
+type DialScript struct {
+	mu    *sync.Mutex //The Dialer's, which reads steps while dialing
+	steps []dialStep
+	next  int
+}
+
+type dialStep func(ctx context.Context, network, address string) (net.Conn, error)
+
+//NewDialer returns a Dialer without scripts
+func NewDialer() *Dialer {
+	return &Dialer{scripts: map[string]*DialScript{}, hosts: map[string][]string{}}
+}
+
+//On returns the script for address, in host:port form
+func (d *Dialer) On(address string) *DialScript {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	script, ok := d.scripts[address]
+	if !ok {
+		script = &DialScript{mu: &d.mu}
+		d.scripts[address] = script
+	}
+	return script
+}
+
+//Host registers the addresses LookupHost returns for host
+func (d *Dialer) Host(host string, addrs ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.hosts[host] = addrs
+}
+
+//LookupHost resolves host from the registered hosts, mirroring net.Resolver.LookupHost
+func (d *Dialer) LookupHost(_ context.Context, host string) ([]string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	addrs, ok := d.hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return append([]string(nil), addrs...), nil
+}
+
+//Dials returns the addresses dialed so far, in order
+func (d *Dialer) Dials() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.dials...)
+}
+
+//DialContext connects to address according to its script
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.mu.Lock()
+	d.dials = append(d.dials, address)
+	var step dialStep = refuse
+	if script, ok := d.scripts[address]; ok && len(script.steps) > 0 {
+		step = script.steps[script.next]
+		if script.next < len(script.steps)-1 {
+			script.next++
+		}
+	}
+	d.mu.Unlock()
+	return step(ctx, network, address)
+}
+
+//Accept makes the dial succeed. handler receives the server side of the connection and runs in its own routine.
+//A nil handler discards everything the client sends
+func (s *DialScript) Accept(handler func(conn net.Conn)) *DialScript {
+	if handler == nil {
+		handler = func(conn net.Conn) {
+			io.Copy(io.Discard, conn)
+		}
+	}
+	return s.add(func(context.Context, string, string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go func() {
+			defer server.Close()
+			handler(server)
+		}()
+		return client, nil
+	})
+}
+
+//Refuse makes the dial fail with connection refused
+func (s *DialScript) Refuse() *DialScript {
+	return s.add(refuse)
+}
+
+//Timeout makes the dial hang until the context is done, like an unreachable host dropping packets
+func (s *DialScript) Timeout() *DialScript {
+	return s.add(func(ctx context.Context, network, address string) (net.Conn, error) {
+		<-ctx.Done()
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = os.ErrDeadlineExceeded //Implements net.Error with Timeout() true, like a real dial timeout
+		}
+		return nil, &net.OpError{Op: "dial", Net: network, Addr: fakeAddr(address), Err: err}
+	})
+}
+
+//Fail makes the dial fail with err
+func (s *DialScript) Fail(err error) *DialScript {
+	return s.add(func(_ context.Context, network, address string) (net.Conn, error) {
+		return nil, &net.OpError{Op: "dial", Net: network, Addr: fakeAddr(address), Err: err}
+	})
+}
+
+func (s *DialScript) add(step dialStep) *DialScript {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, step)
+	return s
+}
+
+func refuse(_ context.Context, network, address string) (net.Conn, error) {
+	return nil, &net.OpError{
+		Op:   "dial",
+		Net:  network,
+		Addr: fakeAddr(address),
+		Err:  &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED},
+	}
+}
+
+//fakeAddr is a net.Addr for error messages
+type fakeAddr string
+
+func (a fakeAddr) Network() string { return "tcp" }
+func (a fakeAddr) String() string  { return string(a) }
@@ -0,0 +1,95 @@
+package mocking_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+//dialFirst is an example of failover logic under test: it returns the first address accepting a connection
+func dialFirst(dial func(ctx context.Context, network, address string) (net.Conn, error), addrs ...string) (net.Conn, error) {
+	var errs []error
+	for _, addr := range addrs {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		conn, err := dial(ctx, "tcp", addr)
+		cancel()
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func TestDialerFailover(t *testing.T) {
+	dialer := mocking.NewDialer()
+	dialer.On("primary:5432").Refuse()
+	dialer.On("replica-1:5432").Timeout()
+	dialer.On("replica-2:5432").Accept(func(conn net.Conn) {
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		conn.Write([]byte("echo: " + line))
+	})
+
+	conn, err := dialFirst(dialer.DialContext, "primary:5432", "replica-1:5432", "replica-2:5432")
+	assert.Nil(t, err)
+	defer conn.Close()
+	conn.Write([]byte("ping\n"))
+	reply, _ := bufio.NewReader(conn).ReadString('\n')
+	assert.Equal(t, "echo: ping\n", reply)
+	assert.Equal(t, []string{"primary:5432", "replica-1:5432", "replica-2:5432"}, dialer.Dials())
+}
+
+func TestDialerErrors(t *testing.T) {
+	dialer := mocking.NewDialer()
+	dialer.On("db:5432").Refuse().Timeout().Accept(nil)
+
+	_, err := dialFirst(dialer.DialContext, "db:5432")
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	assert.EqualError(t, err, "dial tcp db:5432: connect: connection refused")
+
+	_, err = dialFirst(dialer.DialContext, "db:5432")
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	var netErr net.Error
+	if assert.ErrorAs(t, err, &netErr) {
+		assert.True(t, netErr.Timeout())
+	}
+
+	conn, err := dialFirst(dialer.DialContext, "db:5432", "unknown:80")
+	assert.Nil(t, err)
+	conn.Close()
+	conn, err = dialFirst(dialer.DialContext, "db:5432") //The last step repeats
+	assert.Nil(t, err)
+	conn.Close()
+
+	_, err = dialer.LookupHost(context.Background(), "db")
+	var dnsErr *net.DNSError
+	assert.ErrorAs(t, err, &dnsErr)
+	dialer.Host("db", "10.0.0.1", "10.0.0.2")
+	addrs, _ := dialer.LookupHost(context.Background(), "db")
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, addrs)
+}
+
+func TestDialerScriptWhileDialing(t *testing.T) {
+	dialer := mocking.NewDialer()
+	script := dialer.On("db:5432")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			dialer.DialContext(context.Background(), "tcp", "db:5432")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		script.Refuse() //Extending the script races with dials without the Dialer's lock
+	}
+	<-done
+	_, err := dialer.DialContext(context.Background(), "tcp", "db:5432")
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+}
@@ -0,0 +1,115 @@
+package mocking
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//envOwner is the name of the test using Env, guarded by envMu. The environment is global to the process, so
+//parallel tests changing it would see each other's values. Other tests wait on envFree until envDepth drops to 0
+var (
+	envMu    sync.Mutex
+	envFree  = sync.NewCond(&envMu)
+	envOwner string
+	envDepth int
+)
+
+//ownsEnv reports whether the test named name is the owner or one of its subtests
+func ownsEnv(name string) bool {
+	return envDepth > 0 && (name == envOwner || strings.HasPrefix(name, envOwner+"/"))
+}
+
+//Env changes environment variables for the duration of a test:
+//
+//	env := mocking.NewEnv(t)
+//	env.Set("APP_PORT", "8080")
+//	env.Unset("APP_DEBUG")
+//
+//The complete environment is restored when the test finishes. Tests using Env run one at a time, even if they call
+//t.Parallel, so they do not observe each other's changes. NewEnv may be called again by the same test and by its
+//subtests started with t.Run, which share the parent's turn and restore their own changes when they finish.
+//Parallel subtests of a test using Env must not change the environment, since they would run concurrently
+type Env struct {
+	t testing.TB
+}
+
+//NewEnv snapshots the environment and restores it when t finishes. It blocks while another test uses an Env
+func NewEnv(t testing.TB) *Env {
+	t.Helper()
+	envMu.Lock()
+	for envDepth > 0 && !ownsEnv(t.Name()) {
+		envFree.Wait()
+	}
+	if envDepth == 0 {
+		envOwner = t.Name()
+	}
+	envDepth++
+	envMu.Unlock()
+
+	snapshot := SnapshotEnv()
+	t.Cleanup(func() {
+		if err := snapshot.Restore(); err != nil {
+			t.Errorf("restoring environment: %v", err)
+		}
+		envMu.Lock()
+		defer envMu.Unlock()
+		envDepth--
+		if envDepth == 0 {
+			envFree.Broadcast()
+		}
+	})
+	return &Env{t: t}
+}
+
+//Set sets the variable key. Failures fail the test
+func (e *Env) Set(key, value string) *Env {
+	e.t.Helper()
+	if err := os.Setenv(key, value); err != nil {
+		e.t.Fatalf("setting %s: %v", key, err)
+	}
+	return e
+}
+
+//Unset removes the variable key
+func (e *Env) Unset(key string) *Env {
+	e.t.Helper()
+	if err := os.Unsetenv(key); err != nil {
+		e.t.Fatalf("unsetting %s: %v", key, err)
+	}
+	return e
+}
+
+//EnvSnapshot is a copy of the environment
+type EnvSnapshot map[string]string
+
+//SnapshotEnv copies the current environment
+func SnapshotEnv() EnvSnapshot {
+	snapshot := EnvSnapshot{}
+	for _, entry := range os.Environ() {
+		key, value, _ := strings.Cut(entry, "=")
+		snapshot[key] = value
+	}
+	return snapshot
+}
+
+//Restore resets the environment to the snapshot, removing variables added since it was taken
+func (s EnvSnapshot) Restore() error {
+	for _, entry := range os.Environ() {
+		key, _, _ := strings.Cut(entry, "=")
+		if _, ok := s[key]; !ok {
+			if err := os.Unsetenv(key); err != nil {
+				return err
+			}
+		}
+	}
+	for key, value := range s {
+		if current, ok := os.LookupEnv(key); !ok || current != value {
+			if err := os.Setenv(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package mocking_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"os"
+	"testing"
+)
+
+func TestEnv(t *testing.T) {
+	os.Setenv("MOCKING_TEST_KEEP", "original")
+	defer os.Unsetenv("MOCKING_TEST_KEEP")
+
+	t.Run("mutate", func(t *testing.T) {
+		env := mocking.NewEnv(t)
+		env.Set("MOCKING_TEST_NEW", "new").Unset("MOCKING_TEST_KEEP")
+
+		assert.Equal(t, "new", os.Getenv("MOCKING_TEST_NEW"))
+		_, ok := os.LookupEnv("MOCKING_TEST_KEEP")
+		assert.False(t, ok)
+	})
+
+	//Restored after the subtest
+	_, ok := os.LookupEnv("MOCKING_TEST_NEW")
+	assert.False(t, ok)
+	assert.Equal(t, "original", os.Getenv("MOCKING_TEST_KEEP"))
+}
+
+func TestEnvParallel(t *testing.T) {
+	for _, value := range []string{"a", "b", "c"} {
+		value := value
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+			mocking.NewEnv(t).Set("MOCKING_TEST_PARALLEL", value)
+			assert.Equal(t, value, os.Getenv("MOCKING_TEST_PARALLEL")) //Not overwritten by the other subtests
+		})
+	}
+}
+
+func TestEnvNested(t *testing.T) {
+	mocking.NewEnv(t).Set("MOCKING_TEST_PARENT", "parent")
+	mocking.NewEnv(t).Set("MOCKING_TEST_TWICE", "twice") //Calling NewEnv again doesn't deadlock
+
+	t.Run("subtest", func(t *testing.T) {
+		mocking.NewEnv(t).Set("MOCKING_TEST_CHILD", "child")
+		assert.Equal(t, "parent", os.Getenv("MOCKING_TEST_PARENT"))
+	})
+	_, ok := os.LookupEnv("MOCKING_TEST_CHILD") //Restored by the subtest's Env
+	assert.False(t, ok)
+	assert.Equal(t, "twice", os.Getenv("MOCKING_TEST_TWICE"))
+}
+
+func TestEnvSnapshot(t *testing.T) {
+	snapshot := mocking.SnapshotEnv()
+	os.Setenv("MOCKING_TEST_SNAPSHOT", "value")
+
+	assert.Nil(t, snapshot.Restore())
+	_, ok := os.LookupEnv("MOCKING_TEST_SNAPSHOT")
+	assert.False(t, ok)
+}
@@ -0,0 +1,150 @@
+package mocking
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+//RouteOption configures latency and faults of a Route
+type RouteOption func(*faults)
+
+//WithDelay delays every response of the route by d
+func WithDelay(d time.Duration) RouteOption {
+	return func(f *faults) {
+		f.delay = d
+	}
+}
+
+//WithJitter adds a random delay in [0, d) to every response of the route
+func WithJitter(d time.Duration) RouteOption {
+	return func(f *faults) {
+		f.jitter = d
+	}
+}
+
+//WithRandomFailures makes the route respond with status instead of its configured response for the given ratio
+//of calls, e.g. 0.3 for 30%
+func WithRandomFailures(rate float64, status int) RouteOption {
+	return func(f *faults) {
+		f.failureRate = rate
+		f.failureStatus = status
+	}
+}
+
+//WithConnectionReset makes the route reset the connection instead of responding
+func WithConnectionReset() RouteOption {
+	return func(f *faults) {
+		f.reset = true
+	}
+}
+
+//WithFaultSeed seeds the random source for jitter and random failures. Routes use a fixed seed by default, so the
+//sequence of injected faults is the same on every test run
+func WithFaultSeed(seed int64) RouteOption {
+	return func(f *faults) {
+		f.random = rand.New(rand.NewSource(seed))
+	}
+}
+
+//With applies the given latency and fault options to the route:
+//
+//	server.On("GET", "/users").With(mocking.WithDelay(time.Second), mocking.WithRandomFailures(0.5, 503))
+func (r *Route) With(opts ...RouteOption) *Route {
+	for idx := range opts {
+		opts[idx](&r.faults)
+	}
+	return r
+}
+
+type faults struct {
+	delay         time.Duration
+	jitter        time.Duration
+	failureRate   float64
+	failureStatus int
+	reset         bool
+	random        *rand.Rand
+
+	disconnect      bool
+	disconnectAfter int
+}
+
+//faultPlan holds the decisions for a single call
+type faultPlan struct {
+	delay  time.Duration
+	status int //0 for no failure
+	reset  bool
+
+	disconnect      bool
+	disconnectAfter int
+}
+
+//plan decides the faults of the current call. Must be called with the server's lock held, rand.Rand is not safe for
+//concurrent use
+func (f *faults) plan() faultPlan {
+	if f.random == nil {
+		f.random = rand.New(rand.NewSource(1))
+	}
+	p := faultPlan{
+		delay:           f.delay,
+		reset:           f.reset,
+		disconnect:      f.disconnect,
+		disconnectAfter: f.disconnectAfter,
+	}
+	if f.jitter > 0 {
+		p.delay += time.Duration(f.random.Int63n(int64(f.jitter)))
+	}
+	if f.failureRate > 0 && f.random.Float64() < f.failureRate {
+		p.status = f.failureStatus
+	}
+	return p
+}
+
+//apply executes the plan and returns true if it already handled the response
+func (p faultPlan) apply(w http.ResponseWriter, r *http.Request) bool {
+	if !p.wait(r.Context()) {
+		return true //The client gave up, e.g. because of its timeout
+	}
+	if p.reset {
+		resetConnection(w)
+		return true
+	}
+	if p.status != 0 {
+		http.Error(w, "injected failure", p.status)
+		return true
+	}
+	return false
+}
+
+//wait sleeps for the planned delay. It returns false if ctx is done first
+func (p faultPlan) wait(ctx context.Context) bool {
+	if p.delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(p.delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+//resetConnection closes the underlying TCP connection with SO_LINGER 0, so the client receives a RST instead of FIN
+func resetConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic("mocking: connection reset requires a hijackable connection")
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic("mocking: " + err.Error())
+	}
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		tcp.SetLinger(0)
+	}
+	conn.Close()
+}
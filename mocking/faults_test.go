@@ -0,0 +1,57 @@
+package mocking_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRouteDelay(t *testing.T) {
+	server := mocking.NewServer()
+	defer server.Close()
+	server.On("GET", "/slow").With(mocking.WithDelay(50*time.Millisecond), mocking.WithJitter(10*time.Millisecond))
+
+	client := http.Client{Timeout: 10 * time.Millisecond}
+	_, err := client.Get(server.URL() + "/slow")
+	assert.Error(t, err) //Client timeout
+
+	start := time.Now()
+	resp, err := http.Get(server.URL() + "/slow")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestRouteRandomFailures(t *testing.T) {
+	statuses := func() []int {
+		server := mocking.NewServer()
+		defer server.Close()
+		server.On("GET", "/flaky").With(mocking.WithRandomFailures(0.5, http.StatusServiceUnavailable))
+		var result []int
+		for i := 0; i < 20; i++ {
+			resp, err := http.Get(server.URL() + "/flaky")
+			assert.Nil(t, err)
+			result = append(result, resp.StatusCode)
+		}
+		return result
+	}
+
+	first := statuses()
+	assert.Contains(t, first, http.StatusOK)
+	assert.Contains(t, first, http.StatusServiceUnavailable)
+	assert.Equal(t, first, statuses()) //Deterministic across runs
+}
+
+func TestRouteConnectionReset(t *testing.T) {
+	server := mocking.NewServer()
+	defer server.Close()
+	server.On("POST", "/").With(mocking.WithConnectionReset())
+
+	err := mocking.DoPOST(server.URL(), "Hello world")
+	if assert.Error(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "reset") || strings.Contains(err.Error(), "EOF"), err.Error())
+	}
+}
@@ -0,0 +1,46 @@
+package mocking
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"text/template"
+	"time"
+)
+
+//fixtureFuncs are available in fixture templates
+var fixtureFuncs = template.FuncMap{
+	"now": func() string {
+		return time.Now().UTC().Format(time.RFC3339)
+	},
+}
+
+//ReplyFromFile makes the route respond with the contents of a fixture file, so large payloads live in testdata
+//instead of inline byte slices. The file is a text/template rendered on every call with data, and {{now}} yields
+//the current time in RFC 3339 format:
+//
+//	server.On("GET", "/users/1").ReplyFromFile(200, "testdata/user_response.json", map[string]interface{}{"ID": 1})
+//
+//It panics if the file cannot be read or parsed, like regexp.MustCompile
+func (r *Route) ReplyFromFile(status int, filename string, data interface{}) *Route {
+	tmpl, err := template.New(filepath.Base(filename)).Funcs(fixtureFuncs).Option("missingkey=error").ParseFiles(filename)
+	if err != nil {
+		panic("mocking: ReplyFromFile: " + err.Error())
+	}
+	r.reply = response{status: status, template: tmpl, data: data}
+	return r
+}
+
+//render executes the fixture template of resp, if any. Errors become a 500 response, so the test sees them
+func (resp response) render() response {
+	if resp.template == nil {
+		return resp
+	}
+	var body bytes.Buffer
+	if err := resp.template.Execute(&body, resp.data); err != nil {
+		return response{status: http.StatusInternalServerError, body: []byte(fmt.Sprintf("rendering fixture: %v", err))}
+	}
+	resp.body = body.Bytes()
+	return resp
+}
@@ -0,0 +1,46 @@
+package mocking_test
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"minimalgo/mocking"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReplyFromFile(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/users/1").ReplyFromFile(200, "testdata/user_response.json", map[string]interface{}{
+		"ID":   1,
+		"Name": "Paul",
+	})
+	transport.On("GET", "/users/2").ReplyFromFile(200, "testdata/user_response.json", map[string]interface{}{"ID": 2})
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get("http://example/users/1")
+	assert.Nil(t, err)
+	var user struct {
+		ID        int       `json:"id"`
+		Name      string    `json:"name"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&user))
+	assert.Equal(t, 1, user.ID)
+	assert.Equal(t, "Paul", user.Name)
+	assert.WithinDuration(t, time.Now(), user.CreatedAt, time.Minute)
+
+	resp, err = client.Get("http://example/users/2") //Name is missing in the data
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "rendering fixture")
+}
+
+func TestReplyFromFileMissing(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	assert.Panics(t, func() {
+		transport.On("GET", "/users/1").ReplyFromFile(200, "testdata/missing.json", nil)
+	})
+}
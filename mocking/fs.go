@@ -0,0 +1,144 @@
+package mocking
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing/fstest"
+	"time"
+)
+
+//FS abstracts the file system operations most code needs. Production code uses OSFS, tests use a MemFS
+type FS interface {
+	Open(name string) (fs.File, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+}
+
+//OSFS returns an FS backed by the os package
+func OSFS() FS {
+	return osFS{}
+}
+
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error)     { return os.Open(name) }
+func (osFS) ReadFile(name string) ([]byte, error)  { return os.ReadFile(name) }
+func (osFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+//FSOp is an operation of FS, used to target error injection
+type FSOp string
+
+const (
+	OpOpen      FSOp = "open"
+	OpReadFile  FSOp = "read"
+	OpWriteFile FSOp = "write"
+	OpStat      FSOp = "stat"
+)
+
+//MemFS is an in-memory FS. Directories are implied by the files they contain. Leading slashes are ignored, so an
+//absolute and a relative name refer to the same file
+type MemFS struct {
+	mu     sync.Mutex
+	files  fstest.MapFS
+	errors map[string]map[FSOp]error
+}
+
+//NewMemFS returns a MemFS containing files, mapping file names to contents
+func NewMemFS(files map[string]string) *MemFS {
+	m := &MemFS{files: fstest.MapFS{}, errors: map[string]map[FSOp]error{}}
+	for name, data := range files {
+		m.files[cleanPath(name)] = &fstest.MapFile{Data: []byte(data), Mode: 0644, ModTime: time.Now()}
+	}
+	return m
+}
+
+//InjectError makes the given operations on name fail with err, e.g. to simulate a full disk on write. Without ops,
+//all operations fail. Pass a nil err to remove the injected error
+func (m *MemFS) InjectError(name string, err error, ops ...FSOp) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(ops) == 0 {
+		ops = []FSOp{OpOpen, OpReadFile, OpWriteFile, OpStat}
+	}
+	name = cleanPath(name)
+	if m.errors[name] == nil {
+		m.errors[name] = map[FSOp]error{}
+	}
+	for _, op := range ops {
+		if err == nil {
+			delete(m.errors[name], op)
+		} else {
+			m.errors[name][op] = err
+		}
+	}
+}
+
+//Open opens the named file for reading
+func (m *MemFS) Open(name string) (fs.File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.injected(OpOpen, name); err != nil {
+		return nil, err
+	}
+	return m.files.Open(cleanPath(name))
+}
+
+//ReadFile returns the contents of the named file
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.injected(OpReadFile, name); err != nil {
+		return nil, err
+	}
+	return m.files.ReadFile(cleanPath(name))
+}
+
+//WriteFile creates or replaces the named file
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.injected(OpWriteFile, name); err != nil {
+		return err
+	}
+	m.files[cleanPath(name)] = &fstest.MapFile{
+		Data:    append([]byte(nil), data...),
+		Mode:    perm,
+		ModTime: time.Now(),
+	}
+	return nil
+}
+
+//Stat returns information about the named file or directory
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.injected(OpStat, name); err != nil {
+		return nil, err
+	}
+	return m.files.Stat(cleanPath(name))
+}
+
+//injected returns the injected error for op on name, wrapped like the os package does. Must be called with the
+//lock held
+func (m *MemFS) injected(op FSOp, name string) error {
+	err := m.errors[cleanPath(name)][op]
+	if err == nil {
+		return nil
+	}
+	return &fs.PathError{Op: string(op), Path: name, Err: err}
+}
+
+func cleanPath(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
@@ -0,0 +1,68 @@
+package mocking_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/fs"
+	"minimalgo/mocking"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+//loadConfig is an example of code under test that touches the disk
+func loadConfig(files mocking.FS, name string) (string, error) {
+	if _, err := files.Stat(name); errors.Is(err, fs.ErrNotExist) {
+		if err := files.WriteFile(name, []byte("default"), 0644); err != nil {
+			return "", err
+		}
+	}
+	data, err := files.ReadFile(name)
+	return string(data), err
+}
+
+func TestMemFS(t *testing.T) {
+	files := mocking.NewMemFS(map[string]string{"/etc/app.conf": "debug=true"})
+
+	config, err := loadConfig(files, "/etc/app.conf")
+	assert.Nil(t, err)
+	assert.Equal(t, "debug=true", config)
+
+	config, err = loadConfig(files, "/etc/other.conf")
+	assert.Nil(t, err)
+	assert.Equal(t, "default", config)
+
+	file, err := files.Open("etc/app.conf")
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(file)
+	assert.Equal(t, "debug=true", string(data))
+
+	info, err := files.Stat("/etc")
+	assert.Nil(t, err)
+	assert.True(t, info.IsDir())
+}
+
+func TestMemFSInjectError(t *testing.T) {
+	files := mocking.NewMemFS(nil)
+	files.InjectError("/var/app.conf", syscall.ENOSPC, mocking.OpWriteFile)
+
+	_, err := loadConfig(files, "/var/app.conf")
+	assert.ErrorIs(t, err, syscall.ENOSPC)
+	assert.EqualError(t, err, "write /var/app.conf: no space left on device")
+
+	files.InjectError("/var/app.conf", nil, mocking.OpWriteFile)
+	_, err = loadConfig(files, "/var/app.conf")
+	assert.Nil(t, err)
+
+	files.InjectError("/var/app.conf", fs.ErrPermission)
+	_, err = files.Open("/var/app.conf")
+	assert.ErrorIs(t, err, fs.ErrPermission)
+}
+
+func TestOSFS(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.conf")
+	config, err := loadConfig(mocking.OSFS(), name)
+	assert.Nil(t, err)
+	assert.Equal(t, "default", config)
+}
@@ -0,0 +1,134 @@
+package mocking
+
+import (
+	"context"
+	"sync"
+)
+
+//CallGate blocks mocked calls until the test releases them, and counts how many run concurrently. It makes race-prone
+//code like a cache stampede testable without sleeps:
+//
+//	gate := mocking.NewCallGate()
+//	fetch := func(ctx context.Context) error {
+//		if err := gate.Enter(ctx); err != nil {
+//			return err
+//		}
+//		defer gate.Leave()
+//		return nil
+//	}
+//	//Start the code under test in routines, then
+//	gate.AwaitWaiting(1)
+//	gate.Open()
+type CallGate struct {
+	mu          sync.Mutex
+	changed     chan struct{} //Closed and replaced on every state change
+	open        bool
+	permits     int
+	waiting     int
+	inFlight    int
+	maxInFlight int
+	calls       int
+}
+
+//NewCallGate returns a closed CallGate
+func NewCallGate() *CallGate {
+	return &CallGate{changed: make(chan struct{})}
+}
+
+//Enter counts a call as in flight and blocks until the gate lets it through. It returns the context's error if ctx
+//is done first, the call is not in flight then
+func (g *CallGate) Enter(ctx context.Context) error {
+	g.mu.Lock()
+	g.calls++
+	g.inFlight++
+	if g.inFlight > g.maxInFlight {
+		g.maxInFlight = g.inFlight
+	}
+	g.waiting++
+	g.notify()
+	for !g.open && g.permits == 0 {
+		changed := g.changed
+		g.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			g.mu.Lock()
+			g.waiting--
+			g.inFlight--
+			g.notify()
+			g.mu.Unlock()
+			return ctx.Err()
+		}
+		g.mu.Lock()
+	}
+	if !g.open {
+		g.permits--
+	}
+	g.waiting--
+	g.notify()
+	g.mu.Unlock()
+	return nil
+}
+
+//Leave ends a call that entered successfully
+func (g *CallGate) Leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.inFlight--
+	g.notify()
+}
+
+//Release lets n blocked or future calls through
+func (g *CallGate) Release(n int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.permits += n
+	g.notify()
+}
+
+//Open lets all blocked and future calls through
+func (g *CallGate) Open() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.open = true
+	g.notify()
+}
+
+//AwaitWaiting blocks until at least n calls wait at the gate
+func (g *CallGate) AwaitWaiting(n int) {
+	g.mu.Lock()
+	for g.waiting < n {
+		changed := g.changed
+		g.mu.Unlock()
+		<-changed
+		g.mu.Lock()
+	}
+	g.mu.Unlock()
+}
+
+//InFlight returns the number of calls that entered and did not leave yet
+func (g *CallGate) InFlight() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.inFlight
+}
+
+//MaxInFlight returns the highest number of concurrent calls observed
+func (g *CallGate) MaxInFlight() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.maxInFlight
+}
+
+//Calls returns the number of calls that tried to enter
+func (g *CallGate) Calls() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.calls
+}
+
+//notify wakes up everyone waiting for a state change. Must be called with the lock held
+func (g *CallGate) notify() {
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
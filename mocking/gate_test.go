@@ -0,0 +1,98 @@
+package mocking_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"sync"
+	"testing"
+	"time"
+)
+
+//dedupCache is an example of code under test: concurrent lookups of the same key share one fetch
+type dedupCache struct {
+	mu      sync.Mutex
+	pending map[string]chan struct{}
+	values  map[string]string
+	fetch   func(key string) string
+}
+
+func (c *dedupCache) Get(key string) string {
+	c.mu.Lock()
+	if value, ok := c.values[key]; ok {
+		c.mu.Unlock()
+		return value
+	}
+	if done, ok := c.pending[key]; ok {
+		c.mu.Unlock()
+		<-done
+		return c.Get(key)
+	}
+	done := make(chan struct{})
+	c.pending[key] = done
+	c.mu.Unlock()
+
+	value := c.fetch(key)
+	c.mu.Lock()
+	c.values[key] = value
+	delete(c.pending, key)
+	c.mu.Unlock()
+	close(done)
+	return value
+}
+
+func TestCallGateStampede(t *testing.T) {
+	gate := mocking.NewCallGate()
+	cache := &dedupCache{pending: map[string]chan struct{}{}, values: map[string]string{}, fetch: func(key string) string {
+		gate.Enter(context.Background())
+		defer gate.Leave()
+		return "value of " + key
+	}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "value of user", cache.Get("user"))
+		}()
+	}
+	gate.AwaitWaiting(1) //The first fetch is blocked, other lookups pile up behind it
+	gate.Open()
+	wg.Wait()
+
+	assert.Equal(t, 1, gate.Calls()) //No stampede
+	assert.Equal(t, 1, gate.MaxInFlight())
+	assert.Equal(t, 0, gate.InFlight())
+}
+
+func TestCallGateRelease(t *testing.T) {
+	gate := mocking.NewCallGate()
+	done := make(chan struct{}, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			if gate.Enter(context.Background()) == nil {
+				defer gate.Leave()
+			}
+			done <- struct{}{}
+		}()
+	}
+	gate.AwaitWaiting(3)
+	assert.Equal(t, 3, gate.MaxInFlight())
+
+	gate.Release(2)
+	<-done
+	<-done
+	select {
+	case <-done:
+		t.Fatal("third call should still be blocked")
+	case <-time.After(10 * time.Millisecond):
+	}
+	assert.Equal(t, 1, gate.InFlight())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, gate.Enter(ctx), context.Canceled)
+	gate.Release(1)
+	<-done
+}
@@ -0,0 +1,189 @@
+package mocking
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"net"
+	"strings"
+	"sync"
+)
+
+//GRPCServer is a mock gRPC server running in-process over an in-memory connection, configured like Server:
+//
+//	server := mocking.NewGRPCServer()
+//	defer server.Close()
+//	server.On("/users.v1.Users/GetUser").Reply(&userspb.User{Id: 1, Name: "Paul"})
+//	conn, _ := server.Dial()
+//	client := userspb.NewUsersClient(conn)
+//
+//Only unary methods are supported. Requests are recorded as their serialized protobuf bytes, use proto.Unmarshal to
+//inspect them
+type GRPCServer struct {
+	mu        sync.Mutex
+	server    *grpc.Server
+	listener  *bufconn.Listener
+	routes    []*GRPCRoute
+	unmatched []string
+	calls     []GRPCCall
+}
+
+//GRPCCall is a request received by a GRPCServer
+type GRPCCall struct {
+	Method   string
+	Request  []byte
+	Metadata metadata.MD
+}
+
+//GRPCHandler computes the response of a GRPCRoute from the serialized request
+type GRPCHandler func(ctx context.Context, request []byte) (proto.Message, error)
+
+//NewGRPCServer starts a GRPCServer. Close it when done
+func NewGRPCServer() *GRPCServer {
+	s := &GRPCServer{listener: bufconn.Listen(1024 * 1024)}
+	s.server = grpc.NewServer(
+		grpc.UnknownServiceHandler(s.handle), //Receives all methods, no service registration required
+		grpc.ForceServerCodec(rawCodec{}),
+	)
+	go s.server.Serve(s.listener)
+	return s
+}
+
+//Dial returns a client connection to the server. It connects on the first call. The caller must close it
+func (s *GRPCServer) Dial() (*grpc.ClientConn, error) {
+	//passthrough hands the address to the dialer as is, instead of resolving it through DNS
+	return grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+}
+
+//On registers a route for the full method name, e.g. /users.v1.Users/GetUser. Without Reply, ReplyError or Handle,
+//the route responds with codes.Unimplemented
+func (s *GRPCServer) On(method string) *GRPCRoute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	route := &GRPCRoute{method: method}
+	s.routes = append(s.routes, route)
+	return route
+}
+
+//Calls returns all requests the server received so far, in order, including those that matched no route
+func (s *GRPCServer) Calls() []GRPCCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]GRPCCall(nil), s.calls...)
+}
+
+//Close stops the server and verifies all expectations were met. It returns an error listing the routes that were
+//never called and the requests that matched no route
+func (s *GRPCServer) Close() error {
+	s.server.Stop()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var problems []string
+	for _, route := range s.routes {
+		if route.calls == 0 {
+			problems = append(problems, fmt.Sprintf("expected %s was not called", route.method))
+		}
+	}
+	for _, method := range s.unmatched {
+		problems = append(problems, fmt.Sprintf("unexpected request %s", method))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("mock grpc server expectations not met:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+func (s *GRPCServer) handle(_ interface{}, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	var request []byte
+	if err := stream.RecvMsg(&request); err != nil {
+		return err
+	}
+	md, _ := metadata.FromIncomingContext(stream.Context())
+
+	s.mu.Lock()
+	s.calls = append(s.calls, GRPCCall{Method: method, Request: request, Metadata: md})
+	var route *GRPCRoute
+	for _, candidate := range s.routes {
+		if candidate.method == method {
+			route = candidate
+			break
+		}
+	}
+	if route == nil {
+		s.unmatched = append(s.unmatched, method)
+		s.mu.Unlock()
+		return status.Errorf(codes.Unimplemented, "no mock route matched %s", method)
+	}
+	route.calls++
+	handler := route.handler
+	s.mu.Unlock()
+
+	if handler == nil {
+		return status.Errorf(codes.Unimplemented, "mock route %s has no reply", method)
+	}
+	response, err := handler(stream.Context(), request)
+	if err != nil {
+		return err
+	}
+	payload, err := proto.Marshal(response)
+	if err != nil {
+		return status.Errorf(codes.Internal, "marshalling mock response: %v", err)
+	}
+	return stream.SendMsg(&payload)
+}
+
+//GRPCRoute is a mocked method of a GRPCServer
+type GRPCRoute struct {
+	method  string
+	handler GRPCHandler
+	calls   int
+}
+
+//Reply makes the route respond with response
+func (r *GRPCRoute) Reply(response proto.Message) *GRPCRoute {
+	return r.Handle(func(context.Context, []byte) (proto.Message, error) {
+		return response, nil
+	})
+}
+
+//ReplyError makes the route fail with the given status
+func (r *GRPCRoute) ReplyError(code codes.Code, message string) *GRPCRoute {
+	return r.Handle(func(context.Context, []byte) (proto.Message, error) {
+		return nil, status.Error(code, message)
+	})
+}
+
+//Handle computes the response with handler, e.g. to echo fields of the request
+func (r *GRPCRoute) Handle(handler GRPCHandler) *GRPCRoute {
+	r.handler = handler
+	return r
+}
+
+//rawCodec passes messages through as bytes, so the server handles any method without knowing its message types.
+//It replaces the proto codec on the server only, clients are unaffected
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	return *v.(*[]byte), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*[]byte) = append([]byte(nil), data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
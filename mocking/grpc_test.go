@@ -0,0 +1,58 @@
+package mocking_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"minimalgo/mocking"
+	"testing"
+)
+
+func TestGRPCServer(t *testing.T) {
+	server := mocking.NewGRPCServer()
+	server.On("/grpc.health.v1.Health/Check").Reply(&healthpb.HealthCheckResponse{
+		Status: healthpb.HealthCheckResponse_SERVING,
+	})
+
+	conn, err := server.Dial()
+	assert.Nil(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "users"})
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	calls := server.Calls()
+	assert.Len(t, calls, 1)
+	var request healthpb.HealthCheckRequest
+	assert.Nil(t, proto.Unmarshal(calls[0].Request, &request))
+	assert.Equal(t, "users", request.Service)
+	assert.Equal(t, []string{"Bearer token"}, calls[0].Metadata.Get("authorization"))
+	assert.Nil(t, server.Close())
+}
+
+func TestGRPCServerErrors(t *testing.T) {
+	server := mocking.NewGRPCServer()
+	server.On("/grpc.health.v1.Health/Check").ReplyError(codes.Unavailable, "overloaded")
+
+	conn, err := server.Dial()
+	assert.Nil(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Nil(t, err)
+	_, err = stream.Recv() //Streams fail on the first receive
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.EqualError(t, server.Close(), "mock grpc server expectations not met:\n"+
+		"unexpected request /grpc.health.v1.Health/Watch")
+}
@@ -1,5 +1,8 @@
 package mocking
 
+//go:generate go run minimalgo/mocking/mockgen/cmd/mockgen -source ifaces.go -type PersonInterface
+//go:generate go run minimalgo/mocking/mockgen/cmd/mockgen -source ifaces.go -type PersonInterface -spy
+
 type PersonInterface interface {
 	PrintName() string
 	PrintLastName() string
@@ -22,3 +25,13 @@ func (m *Person) PrintLastName() string {
 	}
 	return m.LastName
 }
+
+//NoopPerson is the no-op PersonInterface substituted by NoopIf
+type NoopPerson struct{}
+
+func (NoopPerson) PrintName() string     { return "" }
+func (NoopPerson) PrintLastName() string { return "" }
+
+func init() {
+	RegisterNoop[PersonInterface](NoopPerson{})
+}
@@ -51,3 +51,15 @@ func TestPerson_PrintNamePanic(t *testing.T) {
 	var personInterface mocking.PersonInterface
 	personInterface.PrintName() //this will cause a panic because the interface is nil and there is no implementation to catch the call
 }
+
+func TestPerson_GeneratedMock(t *testing.T) {
+	//Generated by go generate, see ifaces.go
+	mock := &mocking.PersonInterfaceMock{
+		PrintNameFunc: func() string { return "Frank" },
+	}
+	var personInterface mocking.PersonInterface = mock
+
+	assert.Equal(t, "Frank", personInterface.PrintName())
+	assert.Len(t, mock.PrintNameCalls(), 1)
+	assert.Panics(t, func() { personInterface.PrintLastName() }) //Not stubbed
+}
@@ -0,0 +1,210 @@
+package mocking
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+//Message is an email
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+//Mailer sends emails. Notification code should depend on it instead of a concrete mail client
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+//FakeMailer is a Mailer capturing all messages for assertions
+type FakeMailer struct {
+	mu   sync.Mutex
+	sent []Message
+	err  error
+}
+
+//Send records message, or returns the injected error
+func (m *FakeMailer) Send(_ context.Context, message Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, message)
+	return nil
+}
+
+//InjectError makes Send fail with err. Pass nil to remove the injected error
+func (m *FakeMailer) InjectError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+//Sent returns the messages sent so far, in order
+func (m *FakeMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.sent...)
+}
+
+//SMTPServer is a minimal SMTP server capturing messages, for code sending mail with net/smtp or another SMTP
+//client. It supports plain SMTP only, no STARTTLS or AUTH
+type SMTPServer struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []Message
+	conns    map[net.Conn]bool
+	closed   bool //Set by Close, so connections accepted concurrently are closed right away
+}
+
+//NewSMTPServer starts an SMTPServer on a free local port. Close it when done
+func NewSMTPServer() *SMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("mocking: starting SMTP server: " + err.Error())
+	}
+	s := &SMTPServer{listener: listener, conns: map[net.Conn]bool{}}
+	s.wg.Add(1)
+	go s.accept()
+	return s
+}
+
+//Addr returns the address of the server, e.g. 127.0.0.1:41234
+func (s *SMTPServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+//Messages returns the messages received so far, in order. From and To hold the envelope addresses
+func (s *SMTPServer) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+//Close stops the server, including open sessions
+func (s *SMTPServer) Close() error {
+	err := s.listener.Close()
+	s.mu.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *SMTPServer) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return //Closed
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = true
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				conn.Close()
+			}()
+			s.session(textproto.NewConn(conn))
+		}()
+	}
+}
+
+//session handles the commands of one SMTP connection
+func (s *SMTPServer) session(conn *textproto.Conn) {
+	conn.PrintfLine("220 localhost mocking SMTP ready")
+	var envelope Message
+	mailFrom := false //MAIL FROM:<> is the valid null sender, so an empty From doesn't tell whether MAIL was sent
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "HELO", "EHLO":
+			conn.PrintfLine("250 localhost")
+		case "MAIL":
+			envelope, mailFrom = Message{From: address(arg)}, true
+			conn.PrintfLine("250 OK")
+		case "RCPT":
+			envelope.To = append(envelope.To, address(arg))
+			conn.PrintfLine("250 OK")
+		case "DATA":
+			if !mailFrom || len(envelope.To) == 0 {
+				conn.PrintfLine("503 MAIL and RCPT required before DATA")
+				continue
+			}
+			conn.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data := conn.DotReader()
+			message, err := readMessage(data, envelope)
+			io.Copy(io.Discard, data) //Consume the rest of an invalid message, or it is read as commands
+			envelope, mailFrom = Message{}, false
+			if err != nil {
+				conn.PrintfLine("554 %v", err)
+				continue
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			conn.PrintfLine("250 OK")
+		case "RSET":
+			envelope, mailFrom = Message{}, false
+			conn.PrintfLine("250 OK")
+		case "NOOP":
+			conn.PrintfLine("250 OK")
+		case "QUIT":
+			conn.PrintfLine("221 Bye")
+			return
+		default:
+			conn.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+//readMessage parses the DATA of a message
+func readMessage(r io.Reader, envelope Message) (Message, error) {
+	parsed, err := mail.ReadMessage(bufio.NewReader(r))
+	if err != nil {
+		return Message{}, fmt.Errorf("invalid message: %w", err)
+	}
+	body, err := io.ReadAll(parsed.Body)
+	if err != nil {
+		return Message{}, err
+	}
+	envelope.Subject = parsed.Header.Get("Subject")
+	envelope.Body = strings.ReplaceAll(string(body), "\r\n", "\n")
+	return envelope, nil
+}
+
+//address extracts the address from a MAIL FROM:<a@b> or RCPT TO:<a@b> argument
+func address(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ") //Drop parameters like SIZE=
+	return strings.Trim(addr, "<>")
+}
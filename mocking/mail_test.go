@@ -0,0 +1,115 @@
+package mocking_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"minimalgo/mocking"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+//notifySignup is an example of notification code under test
+func notifySignup(ctx context.Context, mailer mocking.Mailer, email string) error {
+	return mailer.Send(ctx, mocking.Message{
+		From:    "noreply@example.com",
+		To:      []string{email},
+		Subject: "Welcome",
+		Body:    "Thanks for signing up!",
+	})
+}
+
+func TestFakeMailer(t *testing.T) {
+	mailer := &mocking.FakeMailer{}
+	assert.Nil(t, notifySignup(context.Background(), mailer, "paul@example.com"))
+
+	sent := mailer.Sent()
+	assert.Len(t, sent, 1)
+	assert.Equal(t, []string{"paul@example.com"}, sent[0].To)
+	assert.Equal(t, "Welcome", sent[0].Subject)
+
+	relayDown := errors.New("relay unavailable")
+	mailer.InjectError(relayDown)
+	assert.ErrorIs(t, notifySignup(context.Background(), mailer, "jill@example.com"), relayDown)
+	assert.Len(t, mailer.Sent(), 1)
+}
+
+func TestSMTPServer(t *testing.T) {
+	server := mocking.NewSMTPServer()
+	defer server.Close()
+
+	message := "Subject: Welcome\r\nFrom: noreply@example.com\r\n\r\nThanks for signing up!\r\n"
+	err := smtp.SendMail(server.Addr(), nil, "noreply@example.com", []string{"paul@example.com", "jill@example.com"},
+		[]byte(message))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []mocking.Message{{
+		From:    "noreply@example.com",
+		To:      []string{"paul@example.com", "jill@example.com"},
+		Subject: "Welcome",
+		Body:    "Thanks for signing up!\n",
+	}}, server.Messages())
+}
+
+func TestSMTPServerInvalidMessage(t *testing.T) {
+	server := mocking.NewSMTPServer()
+	defer server.Close()
+	conn, err := textproto.Dial("tcp", server.Addr())
+	assert.Nil(t, err)
+	defer conn.Close()
+	expect := func(code int, format string, args ...interface{}) {
+		id, err := conn.Cmd(format, args...)
+		assert.Nil(t, err)
+		conn.StartResponse(id)
+		defer conn.EndResponse(id)
+		_, _, err = conn.ReadResponse(code)
+		assert.Nil(t, err)
+	}
+	_, _, err = conn.ReadResponse(220)
+	assert.Nil(t, err)
+
+	expect(250, "MAIL FROM:<>") //Null sender of bounces
+	expect(250, "RCPT TO:<paul@example.com>")
+	expect(354, "DATA")
+	//An invalid header and a body beyond the 4KB read buffer, which must not be read as commands
+	expect(554, "not a header\r\n\r\n%s\r\n.", strings.Repeat(strings.Repeat("x", 99)+"\r\n", 100))
+	expect(250, "NOOP")
+
+	expect(250, "MAIL FROM:<>")
+	expect(250, "RCPT TO:<paul@example.com>")
+	expect(354, "DATA")
+	expect(250, "Subject: Undeliverable\r\n\r\nMailbox full\r\n.")
+	assert.Equal(t, []mocking.Message{{
+		To:      []string{"paul@example.com"},
+		Subject: "Undeliverable",
+		Body:    "Mailbox full\n",
+	}}, server.Messages())
+}
+
+func TestSMTPServerCloseWhileConnecting(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		server := mocking.NewSMTPServer()
+		go func() {
+			//Stays connected until the server closes the connection
+			if conn, err := net.Dial("tcp", server.Addr()); err == nil {
+				io.Copy(io.Discard, conn)
+				conn.Close()
+			}
+		}()
+		closed := make(chan struct{})
+		go func() {
+			server.Close()
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-time.After(time.Second):
+			t.Fatal("Close waits for a connection accepted while closing")
+		}
+	}
+}
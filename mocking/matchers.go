@@ -0,0 +1,121 @@
+package mocking
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//Matcher checks a request against an expectation. It returns an empty string if the request matches, or describes
+//the difference otherwise. The description shows up in near miss reports
+type Matcher func(request RecordedRequest) string
+
+//Match makes the route only match requests accepted by all matchers:
+//
+//	server.On("GET", "/users").Match(mocking.MatchHeader("Authorization", "Bearer *"), mocking.MatchQuery("page", "2"))
+func (r *Route) Match(matchers ...Matcher) *Route {
+	r.matchers = append(r.matchers, matchers...)
+	return r
+}
+
+//MatchHeader matches requests with a header value matching pattern. A * in the pattern matches any text
+func MatchHeader(key, pattern string) Matcher {
+	expr := regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$")
+	return func(request RecordedRequest) string {
+		values := request.Header.Values(key)
+		for _, value := range values {
+			if expr.MatchString(value) {
+				return ""
+			}
+		}
+		if len(values) == 0 {
+			return fmt.Sprintf("header %s missing, expected %q", key, pattern)
+		}
+		return fmt.Sprintf("header %s is %q, expected %q", key, strings.Join(values, ", "), pattern)
+	}
+}
+
+//MatchQuery matches requests with the query parameter key set to value
+func MatchQuery(key, value string) Matcher {
+	return func(request RecordedRequest) string {
+		values, ok := request.Query[key]
+		if !ok {
+			return fmt.Sprintf("query parameter %s missing, expected %q", key, value)
+		}
+		for _, actual := range values {
+			if actual == value {
+				return ""
+			}
+		}
+		return fmt.Sprintf("query parameter %s is %q, expected %q", key, strings.Join(values, ", "), value)
+	}
+}
+
+//MatchJSON matches requests with a JSON body containing partial. Fields missing in partial are ignored, so only
+//the relevant part of the body needs to be specified. partial may be a value encoded to JSON, or a JSON string or
+//[]byte. Mismatches are described per field, e.g. $.user.name is "Jill", expected "Paul"
+func MatchJSON(partial interface{}) Matcher {
+	var data []byte
+	switch v := partial.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			panic("mocking: MatchJSON: " + err.Error())
+		}
+	}
+	var expected interface{}
+	if err := json.Unmarshal(data, &expected); err != nil {
+		panic("mocking: MatchJSON: " + err.Error())
+	}
+	return func(request RecordedRequest) string {
+		var actual interface{}
+		if err := json.Unmarshal(request.Body, &actual); err != nil {
+			return fmt.Sprintf("body is not JSON: %v", err)
+		}
+		return strings.Join(jsonDiff("$", expected, actual), ", ")
+	}
+}
+
+//jsonDiff returns the differences between the decoded JSON values. Objects match if actual contains all fields of
+//expected, all other values must be equal
+func jsonDiff(path string, expected, actual interface{}) []string {
+	expectedObject, ok := expected.(map[string]interface{})
+	if !ok {
+		if reflect.DeepEqual(expected, actual) {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s is %s, expected %s", path, encodeJSON(actual), encodeJSON(expected))}
+	}
+	actualObject, ok := actual.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s is %s, expected an object", path, encodeJSON(actual))}
+	}
+
+	keys := make([]string, 0, len(expectedObject))
+	for key := range expectedObject {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var diffs []string
+	for _, key := range keys {
+		value, ok := actualObject[key]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s.%s is missing", path, key))
+			continue
+		}
+		diffs = append(diffs, jsonDiff(path+"."+key, expectedObject[key], value)...)
+	}
+	return diffs
+}
+
+func encodeJSON(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,74 @@
+package mocking_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMatchers(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/users").Match(mocking.MatchHeader("Authorization", "Bearer *"), mocking.MatchQuery("page", "2"))
+	client := &http.Client{Transport: transport}
+
+	var tests = []struct {
+		Name           string
+		Input          string
+		Authorization  string
+		ExpectedStatus int
+	}{
+		{Name: "match", Input: "/users?page=2", Authorization: "Bearer abc/def", ExpectedStatus: 200},
+		{Name: "wrong page", Input: "/users?page=3", Authorization: "Bearer abc", ExpectedStatus: 404},
+		{Name: "missing header", Input: "/users?page=2", ExpectedStatus: 404},
+		{Name: "wrong scheme", Input: "/users?page=2", Authorization: "Basic abc", ExpectedStatus: 404},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("GET", "http://example"+test.Input, nil)
+			if test.Authorization != "" {
+				request.Header.Set("Authorization", test.Authorization)
+			}
+			resp, err := client.Do(request)
+			assert.Nil(t, err)
+			assert.Equal(t, test.ExpectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestMatchJSON(t *testing.T) {
+	matcher := mocking.MatchJSON(map[string]interface{}{
+		"user": map[string]interface{}{"name": "Paul", "roles": []string{"admin"}},
+	})
+
+	var tests = []struct {
+		Name           string
+		Input          string
+		ExpectedOutput string
+	}{
+		{Name: "extra fields ignored", Input: `{"id":1,"user":{"name":"Paul","age":40,"roles":["admin"]}}`},
+		{Name: "different value", Input: `{"user":{"name":"Jill","roles":["admin"]}}`,
+			ExpectedOutput: `$.user.name is "Jill", expected "Paul"`},
+		{Name: "missing field", Input: `{"user":{"roles":["user"]}}`,
+			ExpectedOutput: `$.user.name is missing, $.user.roles is ["user"], expected ["admin"]`},
+		{Name: "not an object", Input: `{"user":"Paul"}`, ExpectedOutput: `$.user is "Paul", expected an object`},
+		{Name: "no JSON", Input: `Paul`, ExpectedOutput: "body is not JSON: invalid character 'P' looking for beginning of value"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.ExpectedOutput, matcher(mocking.RecordedRequest{Body: []byte(test.Input)}))
+		})
+	}
+}
+
+func TestMatchJSONNearMiss(t *testing.T) {
+	server := mocking.NewServer()
+	server.On("POST", "/users").Match(mocking.MatchJSON(`{"name":"Paul"}`))
+
+	http.Post(server.URL()+"/users", "application/json", strings.NewReader(`{"name":"Jill"}`))
+	assert.EqualError(t, server.Close(), "mock server expectations not met:\n"+
+		"expected POST /users was not called\n"+
+		"\tnear miss POST /users: $.name is \"Jill\", expected \"Paul\"\n"+
+		"unexpected request POST /users")
+}
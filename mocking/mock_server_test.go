@@ -3,44 +3,121 @@ package mocking_test
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
-	"io/ioutil"
 	"minimalgo/mocking"
-	"net"
 	"net/http"
-	"net/http/httptest"
 	"testing"
 )
 
-const mockPort = 55575
+func TestDoPOST(t *testing.T) {
+	server := mocking.NewServer()
+	//Verify the request body and mock the desired response
+	server.On("POST", "/").ExpectBody([]byte("Hello world")).Reply(200, []byte(``))
 
-func startMockServer(t *testing.T, expectedRequest []byte, responseCode int, response []byte) (*httptest.Server, error) {
-	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		data, _ := ioutil.ReadAll(r.Body)
-		defer r.Body.Close()
-		//Example: Verify the request body
-		assert.Equal(t, expectedRequest, data, "Expected %s, Got %s", string(expectedRequest), string(data))
+	//Send the request to the mocked endpoint
+	err := mocking.DoPOST(server.URL(), "Hello world")
+	assert.Nil(t, err)
+	assert.Nil(t, server.Close()) //Fails if the route was not called
+}
 
-		//Mock desired response
-		w.WriteHeader(responseCode)
-		w.Write(response)
-	}))
-	l, err := net.Listen("tcp", fmt.Sprintf(":%d", mockPort))
-	if err != nil {
-		return nil, err
-	}
-	server.Listener = l
-	server.Start()
-	return server, nil
+func TestServerUnmetExpectations(t *testing.T) {
+	server := mocking.NewServer()
+	server.On("POST", "/users").ExpectBody([]byte(`{"name":"Paul"}`)).Reply(201, []byte(`{"id":1}`))
+	server.On("DELETE", "/users/1")
+
+	//Wrong body, the route does not match
+	err := mocking.DoPOST(server.URL()+"/users", `{"name":"Jill"}`)
+	assert.EqualError(t, err, "unexpected response code: 404")
+
+	resp, err := http.Post(server.URL()+"/users", "application/json", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	assert.EqualError(t, server.Close(), "mock server expectations not met:\n"+
+		"expected POST /users was not called\n"+
+		"\tnear miss POST /users: body \"{\\\"name\\\":\\\"Jill\\\"}\", expected \"{\\\"name\\\":\\\"Paul\\\"}\"\n"+
+		"\tnear miss POST /users: body \"\", expected \"{\\\"name\\\":\\\"Paul\\\"}\"\n"+
+		"expected DELETE /users/1 was not called\n"+
+		"unexpected request POST /users\n"+
+		"unexpected request POST /users")
 }
 
-func TestDoPOST(t *testing.T) {
-	server, err := startMockServer(t, []byte("Hello world"), 200, []byte(``))
-	if err != nil {
-		t.Fatal(err.Error())
+func TestServerRequests(t *testing.T) {
+	server := mocking.NewServer()
+	defer server.Close()
+	server.On("POST", "/users").Reply(201, nil)
+
+	mocking.DoPOST(server.URL()+"/users?notify=true", `{"name":"Paul"}`)
+	mocking.DoPOST(server.URL()+"/users", `{"name":"Jill"}`)
+
+	//Verify the interactions after the fact
+	server.AssertCalled(t, "POST", "/users", 2)
+	requests := server.Requests()
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "true", requests[0].Query.Get("notify"))
+	assert.Equal(t, []byte(`{"name":"Jill"}`), requests[1].Body)
+
+	recorder := &recordingT{}
+	assert.False(t, server.AssertCalled(recorder, "GET", "/users", 1))
+	assert.Equal(t, []string{"expected GET /users to be called 1 times, was called 0 times"}, recorder.errors)
+}
+
+//recordingT captures assertion failures instead of failing the test
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestServerSequencedResponses(t *testing.T) {
+	server := mocking.NewServer()
+	defer server.Close()
+	server.On("POST", "/jobs").ReplyOnce(500, nil).ReplyOnce(503, nil).Reply(200, nil)
+
+	//A naive retry loop
+	var errs []error
+	for i := 0; i < 4; i++ {
+		errs = append(errs, mocking.DoPOST(server.URL()+"/jobs", "job"))
 	}
+	assert.EqualError(t, errs[0], "unexpected response code: 500")
+	assert.EqualError(t, errs[1], "unexpected response code: 503")
+	assert.Nil(t, errs[2])
+	assert.Nil(t, errs[3]) //Falls through to the default reply
+}
+
+func TestServerStrictness(t *testing.T) {
+	server := mocking.NewServer()
 	defer server.Close()
+	server.On("GET", "/users").Reply(200, nil)
+	recorder := &recordingT{}
+	server.FailOnUnmatched(recorder)
 
-	//Send the request to the mocked endpoint
-	err = mocking.DoPOST(fmt.Sprintf("http://localhost:%d", mockPort), "Hello world")
+	resp, err := http.Post(server.URL()+"/users", "application/json", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, []string{"unexpected request POST /users\n\tnear miss GET /users: got POST /users"}, recorder.errors)
+
+	recorder = &recordingT{}
+	assert.False(t, server.Verify(recorder))
+	assert.Len(t, recorder.errors, 1)
+}
+
+func TestServerFallback(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/users/1").Reply(200, []byte("Paul"))
+	transport.Fallback(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get("http://example/users/2")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+	resp, err = client.Get("http://example/users/1")
 	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, transport.Verify(t)) //Requests answered by the fallback are expected
 }
@@ -0,0 +1,49 @@
+//Command mockgen generates a mock for an interface, see package minimalgo/mocking/mockgen. Use it with go:generate:
+//
+//	//go:generate go run minimalgo/mocking/mockgen/cmd/mockgen -source ifaces.go -type PersonInterface
+package main
+
+import (
+	"flag"
+	"fmt"
+	"minimalgo/mocking/mockgen"
+	"os"
+	"strings"
+)
+
+func main() {
+	source := flag.String("source", os.Getenv("GOFILE"), "Go file declaring the interface")
+	typeName := flag.String("type", "", "name of the interface to mock")
+	out := flag.String("out", "", "output file, defaults to <type>_mock.go or <type>_spy.go")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated mock")
+	spy := flag.Bool("spy", false, "generate a spy delegating to a real implementation instead of a mock")
+	flag.Parse()
+
+	if *source == "" || *typeName == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	generate, suffix := mockgen.Generate, "_mock.go"
+	if *spy {
+		generate, suffix = mockgen.GenerateSpy, "_spy.go"
+	}
+	if *out == "" {
+		*out = strings.ToLower(*typeName) + suffix
+	}
+	if err := run(generate, *source, *typeName, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "mockgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(generate func(string, []byte, string, string) ([]byte, error), source, typeName, pkg, out string) error {
+	src, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	generated, err := generate(source, src, typeName, pkg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, generated, 0644)
+}
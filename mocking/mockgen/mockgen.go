@@ -0,0 +1,347 @@
+//Package mockgen generates mocks for interfaces. A generated mock has a function field per method to stub it, and
+//records the arguments of every call:
+//
+//	mock := &PersonInterfaceMock{PrintNameFunc: func() string { return "Frank" }}
+//	mock.PrintName()
+//	len(mock.PrintNameCalls()) //1
+//
+//Calling a method without stub panics, so tests fail loudly when the code under test makes unexpected calls.
+//
+//Spies generated by GenerateSpy delegate to a real implementation instead, and record calls in a mocking.Spy
+package mockgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Generate parses the Go source and returns the source of a mock for the interface named iface, in package pkg
+func Generate(filename string, src []byte, iface, pkg string) ([]byte, error) {
+	g, file, err := parse(filename, src, iface, iface+"Mock")
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	g.writeHeader(&buf, file, pkg, "sync")
+	g.writeMock(&buf)
+	return formatted(buf.Bytes())
+}
+
+//GenerateSpy parses the Go source and returns the source of a spy for the interface named iface, in package pkg.
+//The spy delegates to a real implementation and records all calls in a mocking.Spy
+func GenerateSpy(filename string, src []byte, iface, pkg string) ([]byte, error) {
+	g, file, err := parse(filename, src, iface, iface+"Spy")
+	if err != nil {
+		return nil, err
+	}
+	qualifier := "mocking."
+	if pkg == "mocking" {
+		qualifier = ""
+	}
+	var buf bytes.Buffer
+	if qualifier == "" {
+		g.writeHeader(&buf, file, pkg)
+	} else {
+		g.writeHeader(&buf, file, pkg, "minimalgo/mocking")
+	}
+	g.writeSpy(&buf, qualifier)
+	return formatted(buf.Bytes())
+}
+
+func parse(filename string, src []byte, iface, typeName string) (*generator, *ast.File, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	spec, err := findInterface(file, iface)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g := &generator{fset: fset, iface: iface, mock: typeName, usedPackages: map[string]bool{}}
+	for _, field := range spec.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return nil, nil, fmt.Errorf("interface %s: embedded interfaces are not supported", iface)
+		}
+		for _, name := range field.Names {
+			g.methods = append(g.methods, g.method(name.Name, fn))
+		}
+	}
+	return g, file, nil
+}
+
+func formatted(src []byte) ([]byte, error) {
+	out, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return out, nil
+}
+
+func findInterface(file *ast.File, name string) (*ast.InterfaceType, error) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if typeSpec.Name.Name != name {
+				continue
+			}
+			iface, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				return nil, fmt.Errorf("%s is not an interface", name)
+			}
+			return iface, nil
+		}
+	}
+	return nil, fmt.Errorf("interface %s not found", name)
+}
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type generator struct {
+	fset         *token.FileSet
+	iface        string
+	mock         string
+	methods      []method
+	usedPackages map[string]bool
+}
+
+func (g *generator) method(name string, fn *ast.FuncType) method {
+	m := method{name: name}
+	if fn.Params != nil {
+		for _, field := range fn.Params.List {
+			typ := field.Type
+			_, variadic := typ.(*ast.Ellipsis)
+			if variadic {
+				typ = typ.(*ast.Ellipsis).Elt
+			}
+			names := field.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{nil}
+			}
+			for _, ident := range names {
+				p := param{name: "arg" + strconv.Itoa(len(m.params)), typ: g.expr(typ), variadic: variadic}
+				if ident != nil && ident.Name != "_" && !reserved(ident.Name) {
+					p.name = ident.Name
+				}
+				m.params = append(m.params, p)
+			}
+		}
+	}
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			for i := 0; i < len(field.Names) || i == 0; i++ {
+				m.results = append(m.results, g.expr(field.Type))
+			}
+		}
+	}
+	return m
+}
+
+//reserved reports whether name collides with the receivers m and s or the results r0..rN of generated methods
+func reserved(name string) bool {
+	if name == "m" || name == "s" {
+		return true
+	}
+	if len(name) < 2 || name[0] != 'r' {
+		return false
+	}
+	_, err := strconv.Atoi(name[1:])
+	return err == nil
+}
+
+//expr prints a type expression and remembers the packages it references
+func (g *generator) expr(e ast.Expr) string {
+	ast.Inspect(e, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				g.usedPackages[ident.Name] = true
+			}
+		}
+		return true
+	})
+	var buf bytes.Buffer
+	printer.Fprint(&buf, g.fset, e)
+	return buf.String()
+}
+
+//writeHeader writes the package clause and the imports referenced by the interface plus extra
+func (g *generator) writeHeader(buf *bytes.Buffer, file *ast.File, pkg string, extra ...string) {
+	fmt.Fprintf(buf, "// Code generated by mockgen. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	lines := map[string]bool{}
+	for _, path := range extra {
+		lines[fmt.Sprintf("\t%q\n", path)] = true
+	}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if !g.usedPackages[name] {
+			continue
+		}
+		if spec.Name != nil {
+			lines[fmt.Sprintf("\t%s %q\n", spec.Name.Name, path)] = true
+		} else {
+			lines[fmt.Sprintf("\t%q\n", path)] = true
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	var sorted []string
+	for line := range lines {
+		sorted = append(sorted, line)
+	}
+	sort.Strings(sorted)
+	fmt.Fprintf(buf, "import (\n%s)\n\n", strings.Join(sorted, ""))
+}
+
+func (g *generator) writeMock(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "//%s is a mock of %s. Stub methods by setting the function fields\n", g.mock, g.iface)
+	fmt.Fprintf(buf, "type %s struct {\n", g.mock)
+	for _, m := range g.methods {
+		fmt.Fprintf(buf, "\t%sFunc func(%s) %s\n", m.name, m.signature(), m.resultList())
+	}
+	buf.WriteString("\n\tmu sync.Mutex\n")
+	for _, m := range g.methods {
+		fmt.Fprintf(buf, "\tcalls%s []%s\n", m.name, g.callType(m))
+	}
+	buf.WriteString("}\n\n")
+	fmt.Fprintf(buf, "var _ %s = &%s{}\n\n", g.iface, g.mock)
+
+	for _, m := range g.methods {
+		callType := g.callType(m)
+		fmt.Fprintf(buf, "//%s records the arguments of one call to %s\n", callType, m.name)
+		fmt.Fprintf(buf, "type %s struct {\n", callType)
+		for _, p := range m.params {
+			fmt.Fprintf(buf, "\t%s %s\n", exported(p.name), p.fieldType())
+		}
+		buf.WriteString("}\n\n")
+
+		fmt.Fprintf(buf, "//%s calls %sFunc and records the call\n", m.name, m.name)
+		fmt.Fprintf(buf, "func (m *%s) %s(%s) %s {\n", g.mock, m.name, m.signature(), m.resultList())
+		fmt.Fprintf(buf, "\tif m.%sFunc == nil {\n\t\tpanic(\"%s.%s: %sFunc is not set\")\n\t}\n", m.name, g.mock, m.name, m.name)
+		fmt.Fprintf(buf, "\tm.mu.Lock()\n\tm.calls%s = append(m.calls%s, %s{", m.name, m.name, callType)
+		for idx, p := range m.params {
+			if idx > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "%s: %s", exported(p.name), p.name)
+		}
+		buf.WriteString("})\n\tm.mu.Unlock()\n\t")
+		if len(m.results) > 0 {
+			buf.WriteString("return ")
+		}
+		fmt.Fprintf(buf, "m.%sFunc(%s)\n}\n\n", m.name, m.arguments())
+
+		fmt.Fprintf(buf, "//%sCalls returns the arguments of all calls to %s so far\n", m.name, m.name)
+		fmt.Fprintf(buf, "func (m *%s) %sCalls() []%s {\n", g.mock, m.name, callType)
+		fmt.Fprintf(buf, "\tm.mu.Lock()\n\tdefer m.mu.Unlock()\n\treturn append([]%s(nil), m.calls%s...)\n}\n\n", callType, m.name)
+	}
+}
+
+func (g *generator) writeSpy(buf *bytes.Buffer, qualifier string) {
+	fmt.Fprintf(buf, "//%s delegates to a real %s and records every call\n", g.mock, g.iface)
+	fmt.Fprintf(buf, "type %s struct {\n\t*%sSpy[%s]\n}\n\n", g.mock, qualifier, g.iface)
+	fmt.Fprintf(buf, "var _ %s = &%s{}\n\n", g.iface, g.mock)
+	fmt.Fprintf(buf, "//New%s returns a spy delegating to target\n", g.mock)
+	fmt.Fprintf(buf, "func New%s(target %s) *%s {\n", g.mock, g.iface, g.mock)
+	fmt.Fprintf(buf, "\treturn &%s{%sNewSpy[%s](target)}\n}\n\n", g.mock, qualifier, g.iface)
+
+	for _, m := range g.methods {
+		var results []string
+		for idx := range m.results {
+			results = append(results, "r"+strconv.Itoa(idx))
+		}
+		fmt.Fprintf(buf, "//%s calls the target and records the call\n", m.name)
+		fmt.Fprintf(buf, "func (s *%s) %s(%s) %s {\n\t", g.mock, m.name, m.signature(), m.resultList())
+		if len(results) > 0 {
+			fmt.Fprintf(buf, "%s := ", strings.Join(results, ", "))
+		}
+		fmt.Fprintf(buf, "s.Target.%s(%s)\n", m.name, m.arguments())
+		var args []string
+		for _, p := range m.params {
+			args = append(args, p.name)
+		}
+		fmt.Fprintf(buf, "\ts.Record(%q, []interface{}{%s}, []interface{}{%s})\n", m.name, strings.Join(args, ", "),
+			strings.Join(results, ", "))
+		if len(results) > 0 {
+			fmt.Fprintf(buf, "\treturn %s\n", strings.Join(results, ", "))
+		}
+		buf.WriteString("}\n\n")
+	}
+}
+
+func (g *generator) callType(m method) string {
+	return g.mock + m.name + "Call"
+}
+
+func (m method) signature() string {
+	var parts []string
+	for _, p := range m.params {
+		if p.variadic {
+			parts = append(parts, p.name+" ..."+p.typ)
+		} else {
+			parts = append(parts, p.name+" "+p.typ)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (m method) arguments() string {
+	var parts []string
+	for _, p := range m.params {
+		if p.variadic {
+			parts = append(parts, p.name+"...")
+		} else {
+			parts = append(parts, p.name)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (m method) resultList() string {
+	switch len(m.results) {
+	case 0:
+		return ""
+	case 1:
+		return m.results[0]
+	}
+	return "(" + strings.Join(m.results, ", ") + ")"
+}
+
+func (p param) fieldType() string {
+	if p.variadic {
+		return "[]" + p.typ
+	}
+	return p.typ
+}
+
+func exported(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,87 @@
+package mockgen_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"minimalgo/mocking/mockgen"
+	"strings"
+	"testing"
+)
+
+const source = `package store
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Tags(string, ...string)
+}
+`
+
+func TestGenerate(t *testing.T) {
+	generated, err := mockgen.Generate("store.go", []byte(source), "Store", "store")
+	assert.Nil(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "store_mock.go", generated, 0)
+	assert.Nil(t, err)
+	code := string(generated)
+	assert.True(t, strings.HasPrefix(code, "// Code generated by mockgen. DO NOT EDIT."))
+	assert.Contains(t, code, "func (m *StoreMock) Get(ctx context.Context, key string) ([]byte, error) {")
+	assert.Contains(t, code, "func (m *StoreMock) Tags(arg0 string, arg1 ...string) {")
+	assert.Contains(t, code, "m.TagsFunc(arg0, arg1...)")
+	assert.Contains(t, code, "Arg1 []string")
+	assert.Contains(t, code, `"time"`)
+	assert.NotContains(t, code, `"io"`) //Not referenced by the interface
+}
+
+func TestGenerateErrors(t *testing.T) {
+	_, err := mockgen.Generate("store.go", []byte(source), "Missing", "store")
+	assert.EqualError(t, err, "interface Missing not found")
+
+	_, err = mockgen.Generate("store.go", []byte("package store\ntype Store interface{ io.Reader }"), "Store", "store")
+	assert.EqualError(t, err, "interface Store: embedded interfaces are not supported")
+}
+
+func TestGenerateSpy(t *testing.T) {
+	generated, err := mockgen.GenerateSpy("store.go", []byte(source), "Store", "store")
+	assert.Nil(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "store_spy.go", generated, 0)
+	assert.Nil(t, err)
+	code := string(generated)
+	assert.Contains(t, code, `"minimalgo/mocking"`)
+	assert.Contains(t, code, "*mocking.Spy[Store]")
+	assert.Contains(t, code, "r0, r1 := s.Target.Get(ctx, key)")
+	assert.Contains(t, code, `s.Record("Tags", []interface{}{arg0, arg1}, []interface{}{})`)
+}
+
+func TestGenerateReservedNames(t *testing.T) {
+	src := "package doer\n\ntype Doer interface {\n\tDo(m int, s string, r0 bool) error\n}\n"
+	generated, err := mockgen.Generate("doer.go", []byte(src), "Doer", "doer")
+	assert.Nil(t, err)
+
+	//Type check the interface together with its mock, a redeclared receiver only shows up here
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for name, code := range map[string][]byte{"doer.go": []byte(src), "doer_mock.go": generated} {
+		file, err := parser.ParseFile(fset, name, code, 0)
+		assert.Nil(t, err)
+		files = append(files, file)
+	}
+	_, err = (&types.Config{Importer: importer.Default()}).Check("doer", fset, files, nil)
+	assert.Nil(t, err)
+	assert.Contains(t, string(generated), "func (m *DoerMock) Do(arg0 int, arg1 string, arg2 bool) error {")
+
+	generated, err = mockgen.GenerateSpy("doer.go", []byte(src), "Doer", "doer")
+	assert.Nil(t, err)
+	assert.Contains(t, string(generated), "func (s *DoerSpy) Do(arg0 int, arg1 string, arg2 bool) error {")
+}
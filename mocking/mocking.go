@@ -1,27 +1,16 @@
 package mocking
 
 import (
-	"bytes"
-	"fmt"
-	"io/ioutil"
-	"net/http"
+	"context"
 	"strings"
 )
 
+var defaultClient = NewClient()
+
 //DoPOST sends a http request to given url with given body
 func DoPOST(url string, body string) error {
-	request, err := http.NewRequest("POST", url, ioutil.NopCloser(bytes.NewReader([]byte(body))))
-	if err != nil {
-		return err
-	}
-	resp, err := http.DefaultClient.Do(request)
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("unexpected response code: %d", resp.StatusCode)
-	}
-	return nil
+	_, err := defaultClient.DoPOST(context.Background(), url, []byte(body))
+	return err
 }
 
 //GetStringFromDatabase fetches a string by ID from the database, can be overwritten for tests
@@ -36,3 +25,13 @@ func ToUpperCaseFromDatabase(input string) string {
 	//code under test
 	return strings.ToUpper(fromDB)
 }
+
+//ToUpperCase reads key from store and converts the value to upper case. Unlike ToUpperCaseFromDatabase, the
+//database is passed in, so tests can use a FakeStore
+func ToUpperCase(ctx context.Context, store Store, key string) (string, error) {
+	value, err := store.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(value), nil
+}
@@ -0,0 +1,40 @@
+package mocking
+
+import (
+	"reflect"
+	"sync"
+)
+
+var noops sync.Map //reflect.Type of the interface to its noop implementation
+
+//RegisterNoop registers the no-op implementation NoopIf substitutes for nil values of the interface T:
+//
+//	mocking.RegisterNoop[PersonInterface](NoopPerson{})
+func RegisterNoop[T any](noop T) {
+	noops.Store(reflect.TypeOf((*T)(nil)).Elem(), noop)
+}
+
+//NoopIf returns the registered no-op implementation of T if v is nil, and v otherwise. Unlike a plain nil check it
+//also catches typed nils, i.e. an interface holding a nil pointer of a type that does not handle nil receivers.
+//If no no-op is registered for T, v is returned unchanged
+func NoopIf[T any](v T) T {
+	if !IsNil(v) {
+		return v
+	}
+	if noop, ok := noops.Load(reflect.TypeOf((*T)(nil)).Elem()); ok {
+		return noop.(T)
+	}
+	return v
+}
+
+//IsNil returns true if v is nil or an interface holding a nil pointer, map, slice, channel or function
+func IsNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch value := reflect.ValueOf(v); value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
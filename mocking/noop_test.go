@@ -0,0 +1,55 @@
+package mocking_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"testing"
+)
+
+//panickingPerson does not handle nil receivers, unlike mocking.Person
+type panickingPerson struct {
+	name string
+}
+
+func (p *panickingPerson) PrintName() string     { return p.name }
+func (p *panickingPerson) PrintLastName() string { return p.name }
+
+func TestNoopIf(t *testing.T) {
+	var personInterface mocking.PersonInterface
+	assert.Equal(t, "", mocking.NoopIf(personInterface).PrintName()) //No panic, unlike TestPerson_PrintNamePanic
+
+	var typedNil *panickingPerson
+	personInterface = typedNil
+	assert.False(t, personInterface == nil) //The classic typed nil pitfall
+	assert.True(t, mocking.IsNil(personInterface))
+	assert.NotPanics(t, func() { mocking.NoopIf(personInterface).PrintName() })
+
+	personInterface = &panickingPerson{name: "Paul"}
+	assert.Equal(t, "Paul", mocking.NoopIf(personInterface).PrintName())
+}
+
+func TestNoopIfUnregistered(t *testing.T) {
+	type unregistered interface{ Do() }
+	var v unregistered
+	assert.Nil(t, mocking.NoopIf(v)) //Returned unchanged
+}
+
+func TestIsNil(t *testing.T) {
+	var tests = []struct {
+		Name           string
+		Input          interface{}
+		ExpectedOutput bool
+	}{
+		{Name: "nil", Input: nil, ExpectedOutput: true},
+		{Name: "typed nil pointer", Input: (*mocking.Person)(nil), ExpectedOutput: true},
+		{Name: "nil map", Input: map[string]int(nil), ExpectedOutput: true},
+		{Name: "nil func", Input: (func())(nil), ExpectedOutput: true},
+		{Name: "pointer", Input: &mocking.Person{}, ExpectedOutput: false},
+		{Name: "value", Input: 0, ExpectedOutput: false},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.ExpectedOutput, mocking.IsNil(test.Input))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocking
+
+import (
+	"sync"
+)
+
+// PersonInterfaceMock is a mock of PersonInterface. Stub methods by setting the function fields
+type PersonInterfaceMock struct {
+	PrintNameFunc     func() string
+	PrintLastNameFunc func() string
+
+	mu                 sync.Mutex
+	callsPrintName     []PersonInterfaceMockPrintNameCall
+	callsPrintLastName []PersonInterfaceMockPrintLastNameCall
+}
+
+var _ PersonInterface = &PersonInterfaceMock{}
+
+// PersonInterfaceMockPrintNameCall records the arguments of one call to PrintName
+type PersonInterfaceMockPrintNameCall struct {
+}
+
+// PrintName calls PrintNameFunc and records the call
+func (m *PersonInterfaceMock) PrintName() string {
+	if m.PrintNameFunc == nil {
+		panic("PersonInterfaceMock.PrintName: PrintNameFunc is not set")
+	}
+	m.mu.Lock()
+	m.callsPrintName = append(m.callsPrintName, PersonInterfaceMockPrintNameCall{})
+	m.mu.Unlock()
+	return m.PrintNameFunc()
+}
+
+// PrintNameCalls returns the arguments of all calls to PrintName so far
+func (m *PersonInterfaceMock) PrintNameCalls() []PersonInterfaceMockPrintNameCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]PersonInterfaceMockPrintNameCall(nil), m.callsPrintName...)
+}
+
+// PersonInterfaceMockPrintLastNameCall records the arguments of one call to PrintLastName
+type PersonInterfaceMockPrintLastNameCall struct {
+}
+
+// PrintLastName calls PrintLastNameFunc and records the call
+func (m *PersonInterfaceMock) PrintLastName() string {
+	if m.PrintLastNameFunc == nil {
+		panic("PersonInterfaceMock.PrintLastName: PrintLastNameFunc is not set")
+	}
+	m.mu.Lock()
+	m.callsPrintLastName = append(m.callsPrintLastName, PersonInterfaceMockPrintLastNameCall{})
+	m.mu.Unlock()
+	return m.PrintLastNameFunc()
+}
+
+// PrintLastNameCalls returns the arguments of all calls to PrintLastName so far
+func (m *PersonInterfaceMock) PrintLastNameCalls() []PersonInterfaceMockPrintLastNameCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]PersonInterfaceMockPrintLastNameCall(nil), m.callsPrintLastName...)
+}
@@ -0,0 +1,29 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocking
+
+// PersonInterfaceSpy delegates to a real PersonInterface and records every call
+type PersonInterfaceSpy struct {
+	*Spy[PersonInterface]
+}
+
+var _ PersonInterface = &PersonInterfaceSpy{}
+
+// NewPersonInterfaceSpy returns a spy delegating to target
+func NewPersonInterfaceSpy(target PersonInterface) *PersonInterfaceSpy {
+	return &PersonInterfaceSpy{NewSpy[PersonInterface](target)}
+}
+
+// PrintName calls the target and records the call
+func (s *PersonInterfaceSpy) PrintName() string {
+	r0 := s.Target.PrintName()
+	s.Record("PrintName", []interface{}{}, []interface{}{r0})
+	return r0
+}
+
+// PrintLastName calls the target and records the call
+func (s *PersonInterfaceSpy) PrintLastName() string {
+	r0 := s.Target.PrintLastName()
+	s.Record("PrintLastName", []interface{}{}, []interface{}{r0})
+	return r0
+}
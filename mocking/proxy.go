@@ -0,0 +1,186 @@
+package mocking
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+//ProxyMode selects whether a proxy server talks to the real upstream
+type ProxyMode int
+
+const (
+	//Replay answers requests from the golden file, without network access to the upstream
+	Replay ProxyMode = iota
+	//Record forwards requests to the upstream and writes all interactions to the golden file on Close
+	Record
+)
+
+//hopHeaders are not forwarded by the proxy
+var hopHeaders = []string{"Connection", "Keep-Alive", "Transfer-Encoding", "Upgrade", "Proxy-Connection", "Te", "Trailer"}
+
+//Interaction is a recorded request and response pair of a golden file
+type Interaction struct {
+	Method         string      `json:"method"`
+	Path           string      `json:"path"`
+	Query          string      `json:"query,omitempty"`
+	RequestBody    string      `json:"requestBody,omitempty"`
+	Status         int         `json:"status"`
+	ResponseHeader http.Header `json:"responseHeader,omitempty"`
+	ResponseBody   string      `json:"responseBody,omitempty"`
+}
+
+//NewProxyServer starts a Server acting as a recording proxy, turning it into a lightweight VCR for integration
+//tests. Record the interactions with the real upstream once, then replay them offline:
+//
+//	mode := mocking.Replay
+//	if os.Getenv("RECORD") != "" {
+//		mode = mocking.Record
+//	}
+//	server, err := mocking.NewProxyServer("https://api.example.com", "testdata/users.json", mode)
+//
+//In Replay mode, each interaction becomes a route of the server, so Close reports interactions that were not
+//replayed. Requests are matched by method, path, query and body. Repeated requests are answered in recording order
+func NewProxyServer(upstream, goldenFile string, mode ProxyMode) (*Server, error) {
+	if mode == Replay {
+		return replayServer(goldenFile)
+	}
+	target, err := url.Parse(upstream)
+	if err != nil {
+		return nil, err
+	}
+	recorder := &proxyRecorder{
+		upstream: target,
+		file:     goldenFile,
+		client:   &http.Client{Timeout: DefaultTimeout},
+	}
+	s := &Server{router: router{name: "mock server"}, recorder: recorder}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s, nil
+}
+
+func replayServer(goldenFile string) (*Server, error) {
+	data, err := os.ReadFile(goldenFile)
+	if err != nil {
+		return nil, err
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, fmt.Errorf("parsing golden file %s: %w", goldenFile, err)
+	}
+
+	s := NewServer()
+	routes := map[string]*Route{}
+	for _, interaction := range interactions {
+		query, err := url.ParseQuery(interaction.Query)
+		if err != nil {
+			return nil, fmt.Errorf("parsing golden file %s: query of %s %s: %w", goldenFile, interaction.Method,
+				interaction.Path, err)
+		}
+		key := interaction.Method + " " + interaction.Path + "?" + query.Encode() + "\n" + interaction.RequestBody
+		route, ok := routes[key]
+		if !ok {
+			route = s.On(interaction.Method, interaction.Path).ExpectBody([]byte(interaction.RequestBody)).
+				Match(matchRawQuery(query))
+			routes[key] = route
+		}
+		resp := response{
+			status: interaction.Status,
+			body:   []byte(interaction.ResponseBody),
+			header: interaction.ResponseHeader,
+		}
+		route.sequence = append(route.sequence, resp)
+		route.reply = resp //Repeat the last response once the sequence is used up
+	}
+	return s, nil
+}
+
+//matchRawQuery matches requests with exactly the given query parameters, in any order
+func matchRawQuery(query url.Values) Matcher {
+	expected := query.Encode()
+	return func(request RecordedRequest) string {
+		if actual := request.Query.Encode(); actual != expected {
+			return fmt.Sprintf("query %q, expected %q", actual, expected)
+		}
+		return ""
+	}
+}
+
+//proxyRecorder forwards requests to the upstream and records the interactions
+type proxyRecorder struct {
+	upstream *url.URL
+	file     string
+	client   *http.Client
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+func (p *proxyRecorder) serve(w http.ResponseWriter, r *http.Request, body []byte) {
+	target := *p.upstream
+	target.Path = strings.TrimSuffix(target.Path, "/") + r.URL.Path
+	target.RawQuery = r.URL.RawQuery
+	request, err := http.NewRequestWithContext(r.Context(), r.Method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	request.Header = r.Header.Clone()
+	for _, header := range hopHeaders {
+		request.Header.Del(header)
+	}
+	//Let the transport negotiate compression, so it decompresses the response and the golden file stays readable
+	request.Header.Del("Accept-Encoding")
+
+	resp, err := p.client.Do(request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	header := resp.Header.Clone()
+	for _, name := range append(hopHeaders, "Content-Length", "Content-Encoding", "Date") {
+		header.Del(name)
+	}
+
+	p.mu.Lock()
+	p.interactions = append(p.interactions, Interaction{
+		Method:         r.Method,
+		Path:           r.URL.Path,
+		Query:          r.URL.RawQuery,
+		RequestBody:    string(body),
+		Status:         resp.StatusCode,
+		ResponseHeader: header,
+		ResponseBody:   string(respBody),
+	})
+	p.mu.Unlock()
+
+	for key, values := range header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write(respBody)
+}
+
+//save writes the recorded interactions to the golden file
+func (p *proxyRecorder) save() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data, err := json.MarshalIndent(p.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.file, append(data, '\n'), 0644)
+}
@@ -0,0 +1,121 @@
+package mocking_test
+
+import (
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"minimalgo/mocking"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProxyServer(t *testing.T) {
+	goldenFile := filepath.Join(t.TempDir(), "users.json")
+
+	//The real upstream, only reachable while recording
+	upstream := mocking.NewServer()
+	upstream.On("GET", "/api/users/1").Reply(200, []byte(`{"name":"Paul"}`))
+	upstream.On("POST", "/api/users").ReplyOnce(201, []byte(`{"id":2}`)).Reply(409, nil)
+
+	recorder, err := mocking.NewProxyServer(upstream.URL()+"/api", goldenFile, mocking.Record)
+	assert.Nil(t, err)
+	getUser := func(server *mocking.Server) (int, string) {
+		resp, err := http.Get(server.URL() + "/users/1")
+		assert.Nil(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+	createUser := func(server *mocking.Server) int {
+		resp, err := http.Post(server.URL()+"/users", "application/json", strings.NewReader(`{"name":"Jill"}`))
+		assert.Nil(t, err)
+		return resp.StatusCode
+	}
+
+	status, body := getUser(recorder)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `{"name":"Paul"}`, body)
+	assert.Equal(t, 201, createUser(recorder))
+	assert.Equal(t, 409, createUser(recorder))
+	assert.Nil(t, recorder.Close())
+	assert.Nil(t, upstream.Close())
+
+	//Replay works without the upstream
+	replayer, err := mocking.NewProxyServer("", goldenFile, mocking.Replay)
+	assert.Nil(t, err)
+	status, body = getUser(replayer)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `{"name":"Paul"}`, body)
+	assert.Equal(t, 201, createUser(replayer))
+	assert.Equal(t, 409, createUser(replayer))
+	assert.Nil(t, replayer.Close())
+}
+
+func TestProxyServerQuery(t *testing.T) {
+	goldenFile := filepath.Join(t.TempDir(), "items.json")
+	upstream := mocking.NewServer()
+	upstream.On("GET", "/items").Match(mocking.MatchQuery("page", "1")).Reply(200, []byte("first"))
+	upstream.On("GET", "/items").Match(mocking.MatchQuery("page", "2")).Reply(200, []byte("second"))
+	getPage := func(server *mocking.Server, page string) string {
+		resp, err := http.Get(server.URL() + "/items?page=" + page)
+		assert.Nil(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	recorder, err := mocking.NewProxyServer(upstream.URL(), goldenFile, mocking.Record)
+	assert.Nil(t, err)
+	assert.Equal(t, "first", getPage(recorder, "1"))
+	assert.Equal(t, "second", getPage(recorder, "2"))
+	assert.Nil(t, recorder.Close())
+	assert.Nil(t, upstream.Close())
+
+	replayer, err := mocking.NewProxyServer("", goldenFile, mocking.Replay)
+	assert.Nil(t, err)
+	assert.Equal(t, "second", getPage(replayer, "2"))
+	assert.Equal(t, "first", getPage(replayer, "1"))
+	assert.Nil(t, replayer.Close())
+}
+
+func TestProxyServerCompressed(t *testing.T) {
+	goldenFile := filepath.Join(t.TempDir(), "compressed.json")
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write([]byte("plain"))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		writer.Write([]byte("plain"))
+		writer.Close()
+	}))
+	defer upstream.Close()
+	get := func(server *mocking.Server) string {
+		resp, err := http.Get(server.URL() + "/text") //Go's client asks for gzip by itself
+		assert.Nil(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	recorder, err := mocking.NewProxyServer(upstream.URL, goldenFile, mocking.Record)
+	assert.Nil(t, err)
+	assert.Equal(t, "plain", get(recorder))
+	assert.Nil(t, recorder.Close())
+	data, err := os.ReadFile(goldenFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"responseBody": "plain"`)
+	assert.NotContains(t, string(data), "Content-Encoding")
+
+	replayer, err := mocking.NewProxyServer("", goldenFile, mocking.Replay)
+	assert.Nil(t, err)
+	assert.Equal(t, "plain", get(replayer))
+	assert.Nil(t, replayer.Close())
+}
+
+func TestProxyServerMissingGoldenFile(t *testing.T) {
+	_, err := mocking.NewProxyServer("", filepath.Join(t.TempDir(), "missing.json"), mocking.Replay)
+	assert.Error(t, err)
+}
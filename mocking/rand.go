@@ -0,0 +1,91 @@
+package mocking
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+//Rand is a source of random numbers. *rand.Rand implements it, tests inject a deterministic fake
+type Rand interface {
+	Int() int
+	Intn(n int) int
+	Float64() float64
+}
+
+//SeededRand returns a Rand producing the same sequence for the same seed. Unlike the global functions of math/rand
+//it is safe for concurrent use
+func SeededRand(seed int64) Rand {
+	return &lockedRand{random: rand.New(rand.NewSource(seed))}
+}
+
+type lockedRand struct {
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+func (r *lockedRand) Int() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.random.Int()
+}
+
+func (r *lockedRand) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.random.Intn(n)
+}
+
+func (r *lockedRand) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.random.Float64()
+}
+
+//SequenceRand is a Rand returning a fixed sequence of numbers, starting over when it is used up:
+//
+//	random := mocking.NewSequenceRand(4, 8, 15)
+//	random.Intn(10) //4
+//	random.Intn(10) //8
+type SequenceRand struct {
+	mu     sync.Mutex
+	values []int
+	next   int
+}
+
+//NewSequenceRand returns a SequenceRand returning values in order
+func NewSequenceRand(values ...int) *SequenceRand {
+	if len(values) == 0 {
+		panic("mocking: NewSequenceRand requires at least one value")
+	}
+	return &SequenceRand{values: values}
+}
+
+//Int returns the next value
+func (r *SequenceRand) Int() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value := r.values[r.next]
+	r.next = (r.next + 1) % len(r.values)
+	return value
+}
+
+//Intn returns the next value. It panics if the value is not in [0, n), which means the sequence does not fit the
+//code under test
+func (r *SequenceRand) Intn(n int) int {
+	value := r.Int()
+	if value < 0 || value >= n {
+		panic(fmt.Sprintf("mocking: SequenceRand value %d out of range [0, %d)", value, n))
+	}
+	return value
+}
+
+//Float64 returns the next value divided by 100, so 25 yields 0.25. Like Intn, it panics if the value is not in
+//[0, 100), as the result must be in [0, 1)
+func (r *SequenceRand) Float64() float64 {
+	value := r.Int()
+	if value < 0 || value >= 100 {
+		panic(fmt.Sprintf("mocking: SequenceRand value %d out of range [0, 100) for Float64", value))
+	}
+	return float64(value) / 100
+}
@@ -0,0 +1,34 @@
+package mocking_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/channels"
+	"minimalgo/mocking"
+	"testing"
+)
+
+func TestSequenceRand(t *testing.T) {
+	random := mocking.NewSequenceRand(4, 8, 15)
+	assert.Equal(t, 4, random.Intn(10))
+	assert.Equal(t, 8, random.Int())
+	assert.Equal(t, 0.15, random.Float64())
+	assert.Equal(t, 4, random.Int()) //Starts over
+	assert.Panics(t, func() { random.Intn(5) })
+	assert.Panics(t, func() { mocking.NewSequenceRand(100).Float64() }) //Float64 must be less than 1
+}
+
+func TestSeededRand(t *testing.T) {
+	first, second := mocking.SeededRand(42), mocking.SeededRand(42)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first.Intn(100), second.Intn(100))
+	}
+}
+
+func TestGenerateRandomNumbersWithRand(t *testing.T) {
+	random := mocking.NewSequenceRand(3, 1, 4, 1, 5)
+	var numbers []int
+	for n := range channels.GenerateRandomNumbers(5, channels.WithRand(random), channels.WithRange(10, 20)) {
+		numbers = append(numbers, n)
+	}
+	assert.Equal(t, []int{13, 11, 14, 11, 15}, numbers) //Exact values instead of just counts
+}
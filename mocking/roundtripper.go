@@ -0,0 +1,93 @@
+package mocking
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"syscall"
+)
+
+//RoundTripper is an http.RoundTripper answering requests from routes configured like a Server's, without any network:
+//
+//	transport := mocking.NewRoundTripper()
+//	transport.On("GET", "/users/1").Reply(200, []byte(`{"id":1}`))
+//	client := &http.Client{Transport: transport}
+//
+//Routes match on method and path only, so any host can be used in the request URL
+type RoundTripper struct {
+	router
+}
+
+//NewRoundTripper returns a RoundTripper without routes
+func NewRoundTripper() *RoundTripper {
+	return &RoundTripper{router: router{name: "mock round tripper"}}
+}
+
+//RoundTrip implements http.RoundTripper. Requests matching no route receive a 404, unless a Fallback is set
+func (t *RoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, plan, unmatched := t.dispatch(r, body)
+	switch {
+	case unmatched != nil:
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		recorder := httptest.NewRecorder()
+		unmatched.ServeHTTP(recorder, r)
+		result := recorder.Result()
+		result.Request = r
+		return result, nil
+	case !plan.wait(r.Context()):
+		return nil, r.Context().Err()
+	case plan.reset:
+		return nil, errConnectionReset()
+	case plan.status != 0:
+		return newResponse(r, plan.status, []byte("injected failure\n")), nil
+	}
+	result := newResponse(r, resp.status, resp.body)
+	if resp.stream != nil {
+		result.Body = streamBody(r.Context(), resp, plan)
+		result.ContentLength = -1
+		result.Header = http.Header{}
+	}
+	for key, values := range resp.header {
+		result.Header[key] = values
+	}
+	return result, nil
+}
+
+//Close verifies all expectations were met. It returns an error listing the routes that were never called and the
+//requests that matched no route. The RoundTripper remains usable
+func (t *RoundTripper) Close() error {
+	return t.verify()
+}
+
+//errConnectionReset returns the error a client observes when the server resets the connection
+func errConnectionReset() error {
+	return &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+}
+
+func newResponse(r *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Length": []string{strconv.Itoa(len(body))}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}
+}
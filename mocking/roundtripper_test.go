@@ -0,0 +1,55 @@
+package mocking_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"minimalgo/mocking"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRoundTripper(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("POST", "/users").ExpectBody([]byte(`{"name":"Paul"}`)).Reply(201, []byte(`{"id":1}`))
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Post("http://users.example/users", "application/json", strings.NewReader(`{"name":"Paul"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"id":1}`, string(body))
+
+	resp, err = client.Get("http://users.example/unknown")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	assert.Len(t, transport.Requests(), 2)
+	transport.AssertCalled(t, "POST", "/users", 1)
+	assert.EqualError(t, transport.Close(), "mock round tripper expectations not met:\nunexpected request GET /unknown")
+}
+
+func TestRoundTripperFaults(t *testing.T) {
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/reset").With(mocking.WithConnectionReset())
+	transport.On("GET", "/fail").With(mocking.WithRandomFailures(1, http.StatusServiceUnavailable))
+	transport.On("GET", "/slow").With(mocking.WithDelay(time.Second))
+	client := &http.Client{Transport: transport}
+
+	_, err := client.Get("http://example/reset")
+	assert.ErrorIs(t, err, syscall.ECONNRESET)
+
+	resp, err := client.Get("http://example/fail")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	request, _ := http.NewRequestWithContext(ctx, "GET", "http://example/slow", nil)
+	_, err = client.Do(request)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, transport.Close())
+}
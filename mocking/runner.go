@@ -0,0 +1,157 @@
+package mocking
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+//Output is the result of a command
+type Output struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+//ExitError is returned for commands exiting with a non-zero code
+type ExitError struct {
+	Command  string
+	ExitCode int
+	Stderr   []byte
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("%s: exit status %d", e.Command, e.ExitCode)
+}
+
+//Runner runs external commands. Code shelling out should depend on it instead of calling os/exec directly
+type Runner interface {
+	Run(ctx context.Context, name string, args ...string) (Output, error)
+}
+
+//ExecRunner returns a Runner executing commands with os/exec
+func ExecRunner() Runner {
+	return execRunner{}
+}
+
+type execRunner struct{}
+
+func (execRunner) Run(ctx context.Context, name string, args ...string) (Output, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	output := Output{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && ctx.Err() == nil {
+		output.ExitCode = exitErr.ExitCode()
+		return output, &ExitError{Command: commandLine(name, args), ExitCode: output.ExitCode, Stderr: output.Stderr}
+	}
+	return output, err
+}
+
+//FakeRunner is a Runner answering commands from scripts instead of executing them:
+//
+//	runner := mocking.NewFakeRunner()
+//	runner.On("git", "rev-parse", "HEAD").Stdout("4f2a9c1\n")
+//	runner.On("git", "push").Stderr("rejected\n").Exit(1)
+//
+//Commands without script fail like a missing binary
+type FakeRunner struct {
+	mu       sync.Mutex
+	scripts  map[string]*CommandScript
+	commands [][]string
+}
+
+//CommandScript is the scripted result of a command
+type CommandScript struct {
+	output Output
+	err    error
+}
+
+//NewFakeRunner returns a FakeRunner without scripts
+func NewFakeRunner() *FakeRunner {
+	return &FakeRunner{scripts: map[string]*CommandScript{}}
+}
+
+//On returns the script for the command with exactly the given arguments
+func (r *FakeRunner) On(name string, args ...string) *CommandScript {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := commandKey(name, args)
+	script, ok := r.scripts[key]
+	if !ok {
+		script = &CommandScript{}
+		r.scripts[key] = script
+	}
+	return script
+}
+
+//Run returns the scripted result of the command
+func (r *FakeRunner) Run(ctx context.Context, name string, args ...string) (Output, error) {
+	r.mu.Lock()
+	r.commands = append(r.commands, append([]string{name}, args...))
+	script, ok := r.scripts[commandKey(name, args)]
+	r.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return Output{}, err
+	}
+	if !ok {
+		return Output{}, &exec.Error{Name: name, Err: exec.ErrNotFound}
+	}
+	if script.err != nil {
+		return Output{}, script.err
+	}
+	output := script.output
+	if output.ExitCode != 0 {
+		return output, &ExitError{Command: commandLine(name, args), ExitCode: output.ExitCode, Stderr: output.Stderr}
+	}
+	return output, nil
+}
+
+//Commands returns the commands run so far, in order, each as name followed by arguments
+func (r *FakeRunner) Commands() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]string(nil), r.commands...)
+}
+
+//Stdout sets the standard output of the command
+func (s *CommandScript) Stdout(stdout string) *CommandScript {
+	s.output.Stdout = []byte(stdout)
+	return s
+}
+
+//Stderr sets the standard error of the command
+func (s *CommandScript) Stderr(stderr string) *CommandScript {
+	s.output.Stderr = []byte(stderr)
+	return s
+}
+
+//Exit sets the exit code of the command
+func (s *CommandScript) Exit(code int) *CommandScript {
+	s.output.ExitCode = code
+	return s
+}
+
+//Fail makes the command fail to start with err, e.g. a permission error
+func (s *CommandScript) Fail(err error) *CommandScript {
+	s.err = err
+	return s
+}
+
+//commandKey identifies a command by its arguments. Unlike commandLine, arguments containing spaces don't collide
+func commandKey(name string, args []string) string {
+	return strings.Join(append([]string{name}, args...), "\x00")
+}
+
+//commandLine formats a command for messages
+func commandLine(name string, args []string) string {
+	return strings.Join(append([]string{name}, args...), " ")
+}
@@ -0,0 +1,72 @@
+package mocking_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+//currentCommit is an example of code shelling out, under test
+func currentCommit(ctx context.Context, runner mocking.Runner) (string, error) {
+	output, err := runner.Run(ctx, "git", "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output.Stdout)), nil
+}
+
+func TestFakeRunner(t *testing.T) {
+	runner := mocking.NewFakeRunner()
+	runner.On("git", "rev-parse", "HEAD").Stdout("4f2a9c1\n")
+	runner.On("git", "push").Stderr("rejected\n").Exit(1)
+
+	commit, err := currentCommit(context.Background(), runner)
+	assert.Nil(t, err)
+	assert.Equal(t, "4f2a9c1", commit)
+
+	output, err := runner.Run(context.Background(), "git", "push")
+	var exitErr *mocking.ExitError
+	if assert.True(t, errors.As(err, &exitErr)) {
+		assert.Equal(t, 1, exitErr.ExitCode)
+		assert.Equal(t, "git push: exit status 1", exitErr.Error())
+	}
+	assert.Equal(t, "rejected\n", string(output.Stderr))
+
+	_, err = runner.Run(context.Background(), "svn", "update")
+	assert.ErrorIs(t, err, exec.ErrNotFound)
+	assert.Equal(t, [][]string{{"git", "rev-parse", "HEAD"}, {"git", "push"}, {"svn", "update"}}, runner.Commands())
+}
+
+func TestFakeRunnerArguments(t *testing.T) {
+	runner := mocking.NewFakeRunner()
+	runner.On("echo", "a b").Stdout("one\n")
+	runner.On("echo", "a", "b").Stdout("two\n")
+
+	output, err := runner.Run(context.Background(), "echo", "a b")
+	assert.Nil(t, err)
+	assert.Equal(t, "one\n", string(output.Stdout))
+	output, err = runner.Run(context.Background(), "echo", "a", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, "two\n", string(output.Stdout))
+}
+
+func TestExecRunner(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell available")
+	}
+	runner := mocking.ExecRunner()
+
+	output, err := runner.Run(context.Background(), "sh", "-c", "echo out; echo err >&2")
+	assert.Nil(t, err)
+	assert.Equal(t, "out\n", string(output.Stdout))
+	assert.Equal(t, "err\n", string(output.Stderr))
+
+	output, err = runner.Run(context.Background(), "sh", "-c", "exit 3")
+	var exitErr *mocking.ExitError
+	assert.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, output.ExitCode)
+}
@@ -0,0 +1,367 @@
+package mocking
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+//Server is a mock HTTP server configured declaratively:
+//
+//	server := mocking.NewServer()
+//	defer server.Close()
+//	server.On("POST", "/users").ExpectBody([]byte(`{"name":"Paul"}`)).Reply(201, []byte(`{"id":1}`))
+//
+//The server listens on a free port chosen by the OS, so tests using it can run in parallel
+type Server struct {
+	router
+	server    *httptest.Server
+	clientTLS *tls.Config //Only set for TLS servers
+	sockets   []*WebSocketRoute
+	recorder  *proxyRecorder //Only set for proxy servers in Record mode
+}
+
+//router holds the routes and recorded requests shared by Server and RoundTripper
+type router struct {
+	name      string //Used in error messages
+	mu        sync.Mutex
+	routes    []*Route
+	unmatched []RecordedRequest
+	requests  []RecordedRequest
+	strict    TestingT     //Fails the test on unmatched requests if set
+	fallback  http.Handler //Answers unmatched requests if set
+}
+
+//RecordedRequest is a request received by a Server
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+//TestingT is the subset of testing.T used by the assertion helpers
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+//NewServer starts a Server. Close it when done
+func NewServer() *Server {
+	s := &Server{router: router{name: "mock server"}}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+//URL returns the base URL of the server, e.g. http://127.0.0.1:41234
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+//On registers a route for method and path. Configure its expectations and response on the returned Route.
+//Routes are matched in registration order
+func (s *router) On(method, path string) *Route {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	route := &Route{
+		method: method,
+		path:   path,
+		reply:  response{status: http.StatusOK},
+	}
+	s.routes = append(s.routes, route)
+	return route
+}
+
+//Close shuts down the server and verifies all expectations were met. It returns an error listing the routes that
+//were never called and the requests that matched no route
+func (s *Server) Close() error {
+	s.mu.Lock()
+	sockets := s.sockets
+	s.mu.Unlock()
+	var problems []string
+	for _, socket := range sockets {
+		socket.closeAll()
+		socket.mu.Lock()
+		if socket.calls == 0 {
+			problems = append(problems, fmt.Sprintf("expected WebSocket %s was not called", socket.path))
+		}
+		socket.mu.Unlock()
+	}
+	s.server.Close()
+	if s.recorder != nil {
+		if err := s.recorder.save(); err != nil {
+			return fmt.Errorf("writing golden file: %w", err)
+		}
+	}
+	return s.verify(problems...)
+}
+
+//verify returns an error describing unmet expectations. Routes that were not called list the
+//requests that almost matched them, which usually pinpoints the mistake
+func (s *router) verify(problems ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, route := range s.routes {
+		if route.calls == 0 {
+			problems = append(problems, fmt.Sprintf("expected %s was not called", route))
+			for _, request := range s.unmatched {
+				if request.Path == route.path {
+					problems = append(problems, fmt.Sprintf("\tnear miss %s %s: %s", request.Method, request.Path,
+						route.mismatch(request)))
+				}
+			}
+		}
+	}
+	for _, request := range s.unmatched {
+		problems = append(problems, fmt.Sprintf("unexpected request %s %s", request.Method, request.Path))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s expectations not met:\n%s", s.name, strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+//Verify reports unmet expectations as a test failure, see Close
+func (s *router) Verify(t TestingT) bool {
+	t.Helper()
+	if err := s.verify(); err != nil {
+		t.Errorf("%v", err)
+		return false
+	}
+	return true
+}
+
+//FailOnUnmatched fails t as soon as a request matches no route, instead of only reporting it on Close. The request
+//is still answered with 404
+func (s *router) FailOnUnmatched(t TestingT) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.strict = t
+}
+
+//Fallback answers requests matching no route with handler instead of 404. They are not reported as unexpected
+func (s *router) Fallback(handler http.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fallback = handler
+}
+
+//Requests returns all requests the server received so far, in order, including those that matched no route
+func (s *router) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+//AssertCalled verifies that the server received exactly times requests with the given method and path.
+//Asserting after the fact attributes failures to the test instead of the server's handler routine
+func (s *router) AssertCalled(t TestingT, method, path string, times int) bool {
+	t.Helper()
+	count := 0
+	for _, request := range s.Requests() {
+		if request.Method == method && request.Path == path {
+			count++
+		}
+	}
+	if count != times {
+		t.Errorf("expected %s %s to be called %d times, was called %d times", method, path, times, count)
+		return false
+	}
+	return true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if isWebSocketUpgrade(r) {
+		if socket := s.matchWebSocket(r); socket != nil {
+			socket.serve(w, r)
+			return
+		}
+	}
+	body, _ := io.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	if s.recorder != nil {
+		s.record(r, body)
+		s.recorder.serve(w, r, body)
+		return
+	}
+	resp, plan, unmatched := s.dispatch(r, body)
+	if unmatched != nil {
+		unmatched.ServeHTTP(w, r)
+		return
+	}
+	if plan.apply(w, r) {
+		return //The fault replaced the response
+	}
+	for key, values := range resp.header {
+		w.Header()[key] = values
+	}
+	if resp.stream != nil {
+		writeStream(w, r, resp, plan)
+		return
+	}
+	w.WriteHeader(resp.status)
+	w.Write(resp.body)
+}
+
+//dispatch records the request and returns the response and faults of the matching route. If no route matched, it
+//returns the handler for unmatched requests instead
+func (s *router) dispatch(r *http.Request, body []byte) (resp response, plan faultPlan, unmatched http.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	request := s.appendRequest(r, body)
+	route := s.match(request)
+	if route != nil {
+		route.calls++
+		return route.next().render(), route.faults.plan(), nil
+	}
+	if s.fallback != nil {
+		return response{}, faultPlan{}, s.fallback
+	}
+	s.unmatched = append(s.unmatched, request)
+	if s.strict != nil {
+		s.strict.Errorf("unexpected request %s %s%s", request.Method, request.Path, s.nearMisses(request))
+	}
+	return response{}, faultPlan{}, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "no mock route matched", http.StatusNotFound)
+	})
+}
+
+//nearMisses describes why the routes for the request's path did not match. Must be called with the lock held
+func (s *router) nearMisses(request RecordedRequest) string {
+	var misses []string
+	for _, route := range s.routes {
+		if route.path == request.Path {
+			misses = append(misses, fmt.Sprintf("\n\tnear miss %s: %s", route, route.mismatch(request)))
+		}
+	}
+	return strings.Join(misses, "")
+}
+
+//matchWebSocket records the handshake request and returns the WebSocket route for its path, or nil
+func (s *Server) matchWebSocket(r *http.Request) *WebSocketRoute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, socket := range s.sockets {
+		if socket.path == r.URL.Path {
+			s.appendRequest(r, nil)
+			return socket
+		}
+	}
+	return nil
+}
+
+//record records a request
+func (s *router) record(r *http.Request, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appendRequest(r, body)
+}
+
+//appendRequest records a request. Must be called with the lock held
+func (s *router) appendRequest(r *http.Request, body []byte) RecordedRequest {
+	request := RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+	s.requests = append(s.requests, request)
+	return request
+}
+
+//match returns the first route matching request, or nil. Must be called with the lock held
+func (s *router) match(request RecordedRequest) *Route {
+	for _, route := range s.routes {
+		if route.mismatch(request) == "" {
+			return route
+		}
+	}
+	return nil
+}
+
+type response struct {
+	status   int
+	body     []byte
+	header   http.Header
+	stream   [][]byte //Chunks of a streamed body, body is ignored if set
+	interval time.Duration
+	template *template.Template //Renders the body on every call if set
+	data     interface{}
+}
+
+//Route is a mocked endpoint of a Server
+type Route struct {
+	method     string
+	path       string
+	expectBody bool
+	body       []byte
+	matchers   []Matcher
+	reply      response
+	sequence   []response
+	calls      int
+	faults     faults
+}
+
+//ExpectBody makes the route only match requests with exactly the given body
+func (r *Route) ExpectBody(body []byte) *Route {
+	r.expectBody = true
+	r.body = body
+	return r
+}
+
+//Reply sets the status code and body the route responds with. Without Reply, the route responds 200 with empty body.
+//If responses were queued with ReplyOnce, Reply is the fallthrough once they are used up
+func (r *Route) Reply(status int, body []byte) *Route {
+	r.reply = response{status: status, body: body}
+	return r
+}
+
+//ReplyOnce queues a response used for a single call. Queued responses are used in order, which allows scripting
+//scenarios like "fail twice, then succeed" to test retry logic:
+//
+//	server.On("GET", "/users/1").ReplyOnce(500, nil).ReplyOnce(503, nil).Reply(200, user)
+func (r *Route) ReplyOnce(status int, body []byte) *Route {
+	r.sequence = append(r.sequence, response{status: status, body: body})
+	return r
+}
+
+//next returns the response for the current call. Must be called with the server's lock held
+func (r *Route) next() response {
+	if len(r.sequence) == 0 {
+		return r.reply
+	}
+	resp := r.sequence[0]
+	r.sequence = r.sequence[1:]
+	return resp
+}
+
+//mismatch describes why request does not match the route. It returns an empty string if it matches
+func (r *Route) mismatch(request RecordedRequest) string {
+	if request.Method != r.method || request.Path != r.path {
+		return fmt.Sprintf("got %s %s", request.Method, request.Path)
+	}
+	if r.expectBody && !bytes.Equal(r.body, request.Body) {
+		return fmt.Sprintf("body %q, expected %q", request.Body, r.body)
+	}
+	for _, matcher := range r.matchers {
+		if mismatch := matcher(request); mismatch != "" {
+			return mismatch
+		}
+	}
+	return ""
+}
+
+func (r *Route) String() string {
+	return fmt.Sprintf("%s %s", r.method, r.path)
+}
@@ -0,0 +1,57 @@
+package mocking
+
+import (
+	"sync"
+)
+
+//Call is a recorded call of a Spy
+type Call struct {
+	Method  string
+	Args    []interface{}
+	Results []interface{}
+}
+
+//Spy records the calls made to a real implementation of T. Generate a type delegating to the target with
+//mockgen -spy:
+//
+//	spy := mocking.NewPersonInterfaceSpy(&mocking.Person{Name: "Paul"})
+//	spy.PrintName() //"Paul"
+//	spy.Calls("PrintName")[0].Results //[]interface{}{"Paul"}
+type Spy[T any] struct {
+	Target T
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+//NewSpy returns a Spy for target
+func NewSpy[T any](target T) *Spy[T] {
+	return &Spy[T]{Target: target}
+}
+
+//Record records a call. It is called by generated delegation code
+func (s *Spy[T]) Record(method string, args, results []interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, Call{Method: method, Args: args, Results: results})
+}
+
+//Calls returns the calls of method, in order
+func (s *Spy[T]) Calls(method string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var calls []Call
+	for _, call := range s.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+//AllCalls returns all calls, in order
+func (s *Spy[T]) AllCalls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
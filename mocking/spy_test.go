@@ -0,0 +1,23 @@
+package mocking_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"testing"
+)
+
+func TestSpy(t *testing.T) {
+	//Generated by go generate, see ifaces.go
+	spy := mocking.NewPersonInterfaceSpy(&mocking.Person{Name: "Paul", LastName: "Smith"})
+	var personInterface mocking.PersonInterface = spy
+
+	assert.Equal(t, "Paul", personInterface.PrintName()) //Real behavior
+	personInterface.PrintName()
+	personInterface.PrintLastName()
+
+	calls := spy.Calls("PrintName")
+	assert.Len(t, calls, 2)
+	assert.Equal(t, []interface{}{"Paul"}, calls[0].Results)
+	assert.Len(t, spy.AllCalls(), 3)
+	assert.Equal(t, "PrintLastName", spy.AllCalls()[2].Method)
+}
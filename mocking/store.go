@@ -0,0 +1,140 @@
+package mocking
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//ErrNotFound is returned by a Store for keys without value
+var ErrNotFound = errors.New("not found")
+
+//Store is a minimal key-value database
+type Store interface {
+	Get(ctx context.Context, key string) (string, error)
+	Put(ctx context.Context, key, value string) error
+	Delete(ctx context.Context, key string) error
+	Keys(ctx context.Context, prefix string) ([]string, error)
+}
+
+//StoreOp is an operation of Store, used to target error injection
+type StoreOp string
+
+const (
+	OpGet    StoreOp = "get"
+	OpPut    StoreOp = "put"
+	OpDelete StoreOp = "delete"
+	OpKeys   StoreOp = "keys"
+)
+
+//FakeStore is an in-memory Store for tests. It can simulate a slow or failing database
+type FakeStore struct {
+	mu      sync.Mutex
+	data    map[string]string
+	latency time.Duration
+	errors  map[StoreOp]error
+}
+
+//NewFakeStore returns a FakeStore containing data
+func NewFakeStore(data map[string]string) *FakeStore {
+	s := &FakeStore{data: map[string]string{}, errors: map[StoreOp]error{}}
+	for key, value := range data {
+		s.data[key] = value
+	}
+	return s
+}
+
+//SetLatency delays every operation by d. Operations return early with the context's error if it is done first
+func (s *FakeStore) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+//InjectError makes all calls of op fail with err. Pass a nil err to remove the injected error
+func (s *FakeStore) InjectError(op StoreOp, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		delete(s.errors, op)
+	} else {
+		s.errors[op] = err
+	}
+}
+
+//Get returns the value of key, or ErrNotFound
+func (s *FakeStore) Get(ctx context.Context, key string) (string, error) {
+	if err := s.before(ctx, OpGet); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.data[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+//Put creates or updates key
+func (s *FakeStore) Put(ctx context.Context, key, value string) error {
+	if err := s.before(ctx, OpPut); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+	return nil
+}
+
+//Delete removes key. Deleting a missing key returns ErrNotFound
+func (s *FakeStore) Delete(ctx context.Context, key string) error {
+	if err := s.before(ctx, OpDelete); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.data[key]; !ok {
+		return ErrNotFound
+	}
+	delete(s.data, key)
+	return nil
+}
+
+//Keys returns the sorted keys starting with prefix
+func (s *FakeStore) Keys(ctx context.Context, prefix string) ([]string, error) {
+	if err := s.before(ctx, OpKeys); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key := range s.data {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+//before simulates latency and returns the injected error of op, if any
+func (s *FakeStore) before(ctx context.Context, op StoreOp) error {
+	s.mu.Lock()
+	latency, err := s.latency, s.errors[op]
+	s.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package mocking_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"testing"
+	"time"
+)
+
+func TestToUpperCase(t *testing.T) {
+	store := mocking.NewFakeStore(map[string]string{"abc": "mock"})
+
+	result, err := mocking.ToUpperCase(context.Background(), store, "abc")
+	assert.Nil(t, err)
+	assert.Equal(t, "MOCK", result)
+
+	_, err = mocking.ToUpperCase(context.Background(), store, "missing")
+	assert.ErrorIs(t, err, mocking.ErrNotFound)
+}
+
+func TestFakeStore(t *testing.T) {
+	ctx := context.Background()
+	store := mocking.NewFakeStore(nil)
+
+	assert.Nil(t, store.Put(ctx, "users/1", "Paul"))
+	assert.Nil(t, store.Put(ctx, "users/2", "Jill"))
+	assert.Nil(t, store.Put(ctx, "orders/1", "Book"))
+	assert.Nil(t, store.Delete(ctx, "users/2"))
+	assert.ErrorIs(t, store.Delete(ctx, "users/2"), mocking.ErrNotFound)
+
+	keys, err := store.Keys(ctx, "users/")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"users/1"}, keys)
+}
+
+func TestFakeStoreFaults(t *testing.T) {
+	store := mocking.NewFakeStore(map[string]string{"abc": "mock"})
+	unavailable := errors.New("connection refused")
+	store.InjectError(mocking.OpPut, unavailable)
+
+	assert.ErrorIs(t, store.Put(context.Background(), "abc", "new"), unavailable)
+	_, err := store.Get(context.Background(), "abc") //Other operations still work
+	assert.Nil(t, err)
+
+	store.SetLatency(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = store.Get(ctx, "abc")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,119 @@
+package mocking
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//SSEEvent is a server-sent event
+type SSEEvent struct {
+	ID    string
+	Event string //Event type, empty for the default "message"
+	Data  string
+}
+
+//ReplyStream makes the route respond 200 with a chunked body, writing one chunk every interval. Use it to test
+//clients consuming streamed responses. Combine with WithDisconnectAfter to cut the stream
+func (r *Route) ReplyStream(chunks [][]byte, interval time.Duration) *Route {
+	r.reply = response{status: http.StatusOK, stream: chunks, interval: interval}
+	return r
+}
+
+//ReplySSE makes the route respond with server-sent events, one every interval:
+//
+//	server.On("GET", "/events").ReplySSE(time.Second, mocking.SSEEvent{Event: "update", Data: `{"id":1}`})
+func (r *Route) ReplySSE(interval time.Duration, events ...SSEEvent) *Route {
+	chunks := make([][]byte, len(events))
+	for idx, event := range events {
+		chunks[idx] = event.encode()
+	}
+	r.reply = response{
+		status:   http.StatusOK,
+		header:   http.Header{"Content-Type": []string{"text/event-stream"}},
+		stream:   chunks,
+		interval: interval,
+	}
+	return r
+}
+
+//WithDisconnectAfter drops the connection after the given number of chunks of a ReplyStream or ReplySSE response,
+//simulating a stream interrupted midway
+func WithDisconnectAfter(chunks int) RouteOption {
+	return func(f *faults) {
+		f.disconnect = true
+		f.disconnectAfter = chunks
+	}
+}
+
+//encode formats the event in the text/event-stream format
+func (e SSEEvent) encode() []byte {
+	var b strings.Builder
+	if e.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", e.ID)
+	}
+	if e.Event != "" {
+		fmt.Fprintf(&b, "event: %s\n", e.Event)
+	}
+	for _, line := range strings.Split(e.Data, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	return []byte(b.String())
+}
+
+//writeStream writes the chunks of resp with flushes in between. It returns early if the client goes away
+func writeStream(w http.ResponseWriter, r *http.Request, resp response, plan faultPlan) {
+	w.WriteHeader(resp.status)
+	flusher, _ := w.(http.Flusher)
+	for idx, chunk := range resp.stream {
+		if plan.disconnect && idx == plan.disconnectAfter {
+			resetConnection(w)
+			return
+		}
+		if idx > 0 && !sleep(r.Context(), resp.interval) {
+			return
+		}
+		w.Write(chunk)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	if plan.disconnect && plan.disconnectAfter >= len(resp.stream) {
+		resetConnection(w)
+	}
+}
+
+//streamBody returns a body producing the chunks of resp over time, for the RoundTripper
+func streamBody(ctx context.Context, resp response, plan faultPlan) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		for idx, chunk := range resp.stream {
+			if plan.disconnect && idx == plan.disconnectAfter {
+				writer.CloseWithError(errConnectionReset())
+				return
+			}
+			if idx > 0 && !sleep(ctx, resp.interval) {
+				writer.CloseWithError(ctx.Err())
+				return
+			}
+			if _, err := writer.Write(chunk); err != nil {
+				return //The reader was closed
+			}
+		}
+		if plan.disconnect && plan.disconnectAfter >= len(resp.stream) {
+			writer.CloseWithError(errConnectionReset())
+			return
+		}
+		writer.Close()
+	}()
+	return reader
+}
+
+//sleep waits for d and returns false if ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	return faultPlan{delay: d}.wait(ctx)
+}
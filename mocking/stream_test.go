@@ -0,0 +1,67 @@
+package mocking_test
+
+import (
+	"bufio"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"minimalgo/mocking"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplyStream(t *testing.T) {
+	server := mocking.NewServer()
+	defer server.Close()
+	server.On("GET", "/download").ReplyStream([][]byte{[]byte("a"), []byte("b"), []byte("c")}, 10*time.Millisecond)
+
+	start := time.Now()
+	resp, err := http.Get(server.URL() + "/download")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"chunked"}, resp.TransferEncoding)
+	body, err := io.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", string(body))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestReplySSE(t *testing.T) {
+	server := mocking.NewServer()
+	defer server.Close()
+	server.On("GET", "/events").ReplySSE(time.Millisecond,
+		mocking.SSEEvent{ID: "1", Event: "update", Data: "first\nline"},
+		mocking.SSEEvent{ID: "2", Data: "second"},
+	)
+
+	resp, err := http.Get(server.URL() + "/events")
+	assert.Nil(t, err)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "id: 1\nevent: update\ndata: first\ndata: line\n\nid: 2\ndata: second\n\n", string(body))
+}
+
+func TestStreamDisconnect(t *testing.T) {
+	events := []mocking.SSEEvent{{Data: "1"}, {Data: "2"}, {Data: "3"}}
+	server := mocking.NewServer()
+	defer server.Close()
+	server.On("GET", "/events").ReplySSE(time.Millisecond, events...).With(mocking.WithDisconnectAfter(2))
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/events").ReplySSE(time.Millisecond, events...).With(mocking.WithDisconnectAfter(2))
+
+	for name, client := range map[string]*http.Client{"server": http.DefaultClient, "transport": {Transport: transport}} {
+		t.Run(name, func(t *testing.T) {
+			resp, err := client.Get(server.URL() + "/events")
+			assert.Nil(t, err)
+			var received []string
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				if strings.HasPrefix(scanner.Text(), "data: ") {
+					received = append(received, scanner.Text())
+				}
+			}
+			assert.Equal(t, []string{"data: 1", "data: 2"}, received)
+			assert.Error(t, scanner.Err()) //Not a clean end of stream
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package mocking
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+type tlsConfig struct {
+	clientAuth bool
+}
+
+type TLSOption func(*tlsConfig)
+
+//WithClientAuth enables mutual TLS: the server requires a client certificate signed by a CA generated for the
+//server. ClientTLSConfig() contains a matching client certificate
+func WithClientAuth() TLSOption {
+	return func(c *tlsConfig) {
+		c.clientAuth = true
+	}
+}
+
+//NewTLSServer starts a Server serving HTTPS with a generated self-signed certificate. Clients must use
+//ClientTLSConfig() or Client() to trust it
+func NewTLSServer(opts ...TLSOption) *Server {
+	config := tlsConfig{}
+	for idx := range opts {
+		opts[idx](&config)
+	}
+
+	s := &Server{router: router{name: "mock server"}}
+	s.server = httptest.NewUnstartedServer(http.HandlerFunc(s.serveHTTP))
+	var clientCert tls.Certificate
+	if config.clientAuth {
+		ca, caKey := mustGenerateCA()
+		clientCert = mustGenerateClientCert(ca, caKey)
+		pool := x509.NewCertPool()
+		pool.AddCert(ca)
+		s.server.TLS = &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  pool,
+		}
+	}
+	s.server.StartTLS() //Generates the server certificate
+
+	roots := x509.NewCertPool()
+	roots.AddCert(s.server.Certificate())
+	s.clientTLS = &tls.Config{RootCAs: roots}
+	if config.clientAuth {
+		s.clientTLS.Certificates = []tls.Certificate{clientCert}
+	}
+	return s
+}
+
+//ClientTLSConfig returns a TLS configuration trusting the server's certificate and, in mTLS mode, presenting a valid
+//client certificate. It returns nil for a plain HTTP server
+func (s *Server) ClientTLSConfig() *tls.Config {
+	if s.clientTLS == nil {
+		return nil
+	}
+	return s.clientTLS.Clone()
+}
+
+//Client returns an http.Client configured to talk to the server
+func (s *Server) Client() *http.Client {
+	if s.clientTLS == nil {
+		return &http.Client{}
+	}
+	return &http.Client{
+		Transport: &http.Transport{TLSClientConfig: s.ClientTLSConfig()},
+	}
+}
+
+func mustGenerateCA() (*x509.Certificate, *ecdsa.PrivateKey) {
+	key := mustGenerateKey()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mocking test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic("mocking: creating CA certificate: " + err.Error())
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic("mocking: parsing CA certificate: " + err.Error())
+	}
+	return ca, key
+}
+
+func mustGenerateClientCert(ca *x509.Certificate, caKey *ecdsa.PrivateKey) tls.Certificate {
+	key := mustGenerateKey()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "mocking test client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		panic("mocking: creating client certificate: " + err.Error())
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+}
+
+func mustGenerateKey() *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic("mocking: generating key: " + err.Error())
+	}
+	return key
+}
@@ -0,0 +1,44 @@
+package mocking_test
+
+import (
+	"crypto/tls"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTLSServer(t *testing.T) {
+	server := mocking.NewTLSServer()
+	defer server.Close()
+	server.On("GET", "/secure").Reply(200, []byte("ok"))
+
+	assert.True(t, strings.HasPrefix(server.URL(), "https://"))
+
+	_, err := http.Get(server.URL() + "/secure")
+	assert.Error(t, err) //The default client does not trust the self-signed certificate
+
+	resp, err := server.Client().Get(server.URL() + "/secure")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestMutualTLSServer(t *testing.T) {
+	server := mocking.NewTLSServer(mocking.WithClientAuth())
+	defer server.Close()
+	server.On("GET", "/secure").Reply(200, nil)
+
+	//Trusting the server is not enough, a client certificate is required
+	config := server.ClientTLSConfig()
+	config.Certificates = nil
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+	_, err := client.Get(server.URL() + "/secure")
+	assert.Error(t, err)
+
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: server.ClientTLSConfig()}}
+	resp, err := client.Get(server.URL() + "/secure")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, uint16(tls.VersionTLS13), resp.TLS.Version)
+}
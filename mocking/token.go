@@ -0,0 +1,88 @@
+package mocking
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//Token is an access token, modeled after oauth2.Token
+type Token struct {
+	AccessToken string
+	TokenType   string
+	Expiry      time.Time
+}
+
+//TokenSource returns valid tokens, refreshing them as needed. It has the shape of oauth2.TokenSource
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+//FakeTokenSource issues numbered tokens expiring after a fixed lifetime on a Clock. Combined with a FakeClock, tests
+//control exactly when tokens expire:
+//
+//	clock := mocking.NewFakeClock(time.Now())
+//	tokens := mocking.NewFakeTokenSource(clock, time.Hour)
+//	tokens.Token() //token-1
+//	clock.Advance(time.Hour)
+//	tokens.Token() //token-2, the first one expired
+type FakeTokenSource struct {
+	clock    Clock
+	lifetime time.Duration
+
+	mu       sync.Mutex
+	current  *Token
+	issued   int
+	failures []error
+}
+
+//NewFakeTokenSource returns a FakeTokenSource issuing tokens valid for lifetime
+func NewFakeTokenSource(clock Clock, lifetime time.Duration) *FakeTokenSource {
+	return &FakeTokenSource{clock: clock, lifetime: lifetime}
+}
+
+//Token returns the current token, or issues a new one if it expired
+func (s *FakeTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	if s.current != nil && now.Before(s.current.Expiry) {
+		token := *s.current
+		return &token, nil
+	}
+	if len(s.failures) > 0 {
+		err := s.failures[0]
+		s.failures = s.failures[1:]
+		return nil, err
+	}
+	s.issued++
+	s.current = &Token{
+		AccessToken: fmt.Sprintf("token-%d", s.issued),
+		TokenType:   "Bearer",
+		Expiry:      now.Add(s.lifetime),
+	}
+	token := *s.current
+	return &token, nil
+}
+
+//Expire invalidates the current token, e.g. to simulate a token revoked by the server
+func (s *FakeTokenSource) Expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = nil
+}
+
+//FailRefresh makes the next refreshes fail with the given errors, one per refresh. Valid tokens are still returned
+//until they expire
+func (s *FakeTokenSource) FailRefresh(errs ...error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, errs...)
+}
+
+//Issued returns the number of tokens issued so far
+func (s *FakeTokenSource) Issued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issued
+}
@@ -0,0 +1,63 @@
+package mocking_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/mocking"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFakeTokenSource(t *testing.T) {
+	clock := mocking.NewFakeClock(clockStart)
+	tokens := mocking.NewFakeTokenSource(clock, time.Hour)
+
+	token, err := tokens.Token()
+	assert.Nil(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+	assert.Equal(t, clockStart.Add(time.Hour), token.Expiry)
+
+	clock.Advance(59 * time.Minute)
+	token, _ = tokens.Token()
+	assert.Equal(t, "token-1", token.AccessToken) //Still valid
+
+	clock.Advance(time.Minute)
+	token, _ = tokens.Token()
+	assert.Equal(t, "token-2", token.AccessToken)
+
+	unavailable := errors.New("identity provider unavailable")
+	tokens.FailRefresh(unavailable)
+	tokens.Expire()
+	_, err = tokens.Token()
+	assert.ErrorIs(t, err, unavailable)
+	token, err = tokens.Token() //Only the first refresh fails
+	assert.Nil(t, err)
+	assert.Equal(t, "token-3", token.AccessToken)
+	assert.Equal(t, 3, tokens.Issued())
+}
+
+func TestClientTokenRefresh(t *testing.T) {
+	clock := mocking.NewFakeClock(clockStart)
+	tokens := mocking.NewFakeTokenSource(clock, time.Minute)
+	transport := mocking.NewRoundTripper()
+	transport.On("GET", "/users").Match(mocking.MatchHeader("Authorization", "Bearer token-1")).ReplyOnce(200, nil)
+	transport.On("GET", "/users").Match(mocking.MatchHeader("Authorization", "Bearer token-2")).ReplyOnce(200, nil)
+	client := mocking.NewClient(
+		mocking.WithHTTPClient(&http.Client{Transport: transport}),
+		mocking.WithTokenSource(tokens),
+	)
+
+	_, err := client.DoGET(context.Background(), "http://example/users")
+	assert.Nil(t, err)
+	clock.Advance(time.Minute)
+	_, err = client.DoGET(context.Background(), "http://example/users")
+	assert.Nil(t, err)
+	assert.Nil(t, transport.Close())
+
+	tokens.Expire()
+	tokens.FailRefresh(errors.New("invalid_grant"))
+	_, err = client.DoGET(context.Background(), "http://example/users")
+	assert.EqualError(t, err, "fetching token: invalid_grant")
+}
@@ -0,0 +1,347 @@
+package mocking
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+//websocketGUID is appended to the client's key to compute the handshake response, see RFC 6455
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+//WebSocketRoute is a mocked WebSocket endpoint of a Server. By default, it sends the scripted messages after the
+//upgrade and then records all messages it receives until the client closes the connection:
+//
+//	server.OnWebSocket("/events").Send("hello", "world").CloseAbruptly()
+type WebSocketRoute struct {
+	path    string
+	script  [][]byte
+	abort   bool
+	handler func(conn *WebSocketConn)
+
+	mu       sync.Mutex
+	calls    int
+	received [][]byte
+	conns    []*WebSocketConn
+}
+
+//OnWebSocket registers a WebSocket endpoint for path
+func (s *Server) OnWebSocket(path string) *WebSocketRoute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	route := &WebSocketRoute{path: path}
+	s.sockets = append(s.sockets, route)
+	return route
+}
+
+//Send queues text messages sent to the client right after the upgrade
+func (r *WebSocketRoute) Send(messages ...string) *WebSocketRoute {
+	for _, message := range messages {
+		r.script = append(r.script, []byte(message))
+	}
+	return r
+}
+
+//CloseAbruptly drops the TCP connection after the scripted messages were sent, without a close frame. This simulates
+//a crashed server or a broken network
+func (r *WebSocketRoute) CloseAbruptly() *WebSocketRoute {
+	r.abort = true
+	return r
+}
+
+//Handle replaces the scripted behavior with handler, which owns the connection until it returns
+func (r *WebSocketRoute) Handle(handler func(conn *WebSocketConn)) *WebSocketRoute {
+	r.handler = handler
+	return r
+}
+
+//Received returns the messages received from clients so far, in order
+func (r *WebSocketRoute) Received() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]byte(nil), r.received...)
+}
+
+func (r *WebSocketRoute) serve(w http.ResponseWriter, req *http.Request) {
+	key := req.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket requires a hijackable connection", http.StatusInternalServerError)
+		return
+	}
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: %s\r\n\r\n", acceptKey(key))
+	if err := rw.Flush(); err != nil {
+		netConn.Close()
+		return
+	}
+
+	conn := &WebSocketConn{conn: netConn, reader: rw.Reader}
+	r.mu.Lock()
+	r.calls++
+	r.conns = append(r.conns, conn)
+	r.mu.Unlock()
+
+	if r.handler != nil {
+		r.handler(conn)
+		conn.Close()
+		return
+	}
+	for _, message := range r.script {
+		if err := conn.WriteMessage(message); err != nil {
+			return
+		}
+	}
+	if r.abort {
+		conn.Abort()
+		return
+	}
+	for {
+		message, err := conn.ReadMessage()
+		if err != nil {
+			conn.Abort()
+			return
+		}
+		r.mu.Lock()
+		r.received = append(r.received, message)
+		r.mu.Unlock()
+	}
+}
+
+//closeAll drops all connections, so Server.Close does not wait for them
+func (r *WebSocketRoute) closeAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, conn := range r.conns {
+		conn.Abort()
+	}
+}
+
+//WebSocketConn is one side of a WebSocket connection. It implements the parts of RFC 6455 needed for tests: text
+//and binary messages, fragmentation, ping and close. Extensions and subprotocols are not supported
+type WebSocketConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	client bool //Clients mask their frames
+
+	writeMu sync.Mutex
+	closed  bool
+}
+
+//DialWebSocket connects to a WebSocket endpoint. The URL may use the ws or http scheme, e.g. server.URL()+"/events"
+func DialWebSocket(ctx context.Context, rawURL string) (*WebSocketConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "ws" && u.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported websocket scheme %q", u.Scheme)
+	}
+	var dialer net.Dialer
+	netConn, err := dialer.DialContext(ctx, "tcp", u.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+	u.Scheme = "http"
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Sec-WebSocket-Key", key)
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	if err := request.Write(netConn); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	reader := bufio.NewReader(netConn)
+	resp, err := http.ReadResponse(reader, request)
+	if err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		netConn.Close()
+		return nil, fmt.Errorf("websocket handshake failed: %s", resp.Status)
+	}
+	return &WebSocketConn{conn: netConn, reader: reader, client: true}, nil
+}
+
+//ReadMessage returns the next text or binary message. It answers pings, and returns io.EOF once the peer closed
+//the connection gracefully
+func (c *WebSocketConn) ReadMessage() ([]byte, error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			c.Close()
+			return nil, io.EOF
+		}
+		message = append(message, payload...)
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+//WriteMessage sends a text message
+func (c *WebSocketConn) WriteMessage(message []byte) error {
+	return c.writeFrame(opText, message)
+}
+
+//WriteBinary sends a binary message
+func (c *WebSocketConn) WriteBinary(message []byte) error {
+	return c.writeFrame(opBinary, message)
+}
+
+//Close sends a close frame and closes the connection
+func (c *WebSocketConn) Close() error {
+	status := make([]byte, 2)
+	binary.BigEndian.PutUint16(status, 1000) //Normal closure
+	c.writeFrame(opClose, status)
+	return c.Abort()
+}
+
+//Abort closes the connection without a close frame
+func (c *WebSocketConn) Abort() error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.closed = true
+	return c.conn.Close()
+}
+
+func (c *WebSocketConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	header := make([]byte, 2)
+	if _, err = io.ReadFull(c.reader, header); err != nil {
+		return false, 0, nil, err
+	}
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err = io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err = io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended)
+	}
+	if length > 1<<24 {
+		return false, 0, nil, errors.New("websocket frame too large")
+	}
+	var mask []byte
+	if masked {
+		mask = make([]byte, 4)
+		if _, err = io.ReadFull(c.reader, mask); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for idx := range mask {
+		for i := idx; i < len(payload); i += 4 {
+			payload[i] ^= mask[idx]
+		}
+	}
+	if opcode == opContinuation {
+		opcode = opText
+	}
+	return fin, opcode, payload, nil
+}
+
+func (c *WebSocketConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+
+	frame := []byte{0x80 | opcode}
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, maskBit|byte(length))
+	case length <= 0xFFFF:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+	if c.client {
+		mask := make([]byte, 4)
+		rand.Read(mask)
+		frame = append(frame, mask...)
+		for idx, b := range payload {
+			frame = append(frame, b^mask[idx%4])
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+func acceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+//isWebSocketUpgrade returns true for WebSocket handshake requests
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
@@ -0,0 +1,71 @@
+package mocking_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"minimalgo/mocking"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebSocket(t *testing.T) {
+	server := mocking.NewServer()
+	route := server.OnWebSocket("/events").Send("hello", strings.Repeat("x", 70000))
+
+	conn, err := mocking.DialWebSocket(context.Background(), server.URL()+"/events")
+	assert.Nil(t, err)
+	message, err := conn.ReadMessage()
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(message))
+	message, _ = conn.ReadMessage()
+	assert.Len(t, message, 70000)
+
+	assert.Nil(t, conn.WriteMessage([]byte("subscribe")))
+	assert.Nil(t, conn.WriteMessage([]byte("unsubscribe")))
+	conn.Close()
+
+	assert.Eventually(t, func() bool { return len(route.Received()) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, "subscribe", string(route.Received()[0]))
+	assert.Nil(t, server.Close())
+}
+
+func TestWebSocketCloseAbruptly(t *testing.T) {
+	server := mocking.NewServer()
+	defer server.Close()
+	server.OnWebSocket("/events").Send("hello").CloseAbruptly()
+
+	conn, err := mocking.DialWebSocket(context.Background(), server.URL()+"/events")
+	assert.Nil(t, err)
+	defer conn.Abort()
+	_, err = conn.ReadMessage()
+	assert.Nil(t, err)
+
+	_, err = conn.ReadMessage()
+	assert.ErrorIs(t, err, io.EOF) //Connection dropped without close frame
+}
+
+func TestWebSocketHandle(t *testing.T) {
+	server := mocking.NewServer()
+	server.OnWebSocket("/echo").Handle(func(conn *mocking.WebSocketConn) {
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteMessage(append([]byte("echo: "), message...))
+		}
+	})
+	server.OnWebSocket("/unused")
+
+	conn, err := mocking.DialWebSocket(context.Background(), server.URL()+"/echo")
+	assert.Nil(t, err)
+	conn.WriteMessage([]byte("ping"))
+	message, err := conn.ReadMessage()
+	assert.Nil(t, err)
+	assert.Equal(t, "echo: ping", string(message))
+	conn.Close()
+
+	assert.EqualError(t, server.Close(), "mock server expectations not met:\nexpected WebSocket /unused was not called")
+}
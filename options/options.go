@@ -1,26 +1,34 @@
 package options
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+)
 
-type connection struct {
+//Connection is a TCP client connection, configured with options and opened with Dial
+type Connection struct {
 	ip   string
 	port int
+	conn net.Conn
 }
-type connectionOption func(*connection)
+type connectionOption func(*Connection)
 
-func WithIP(ip string) func(connection *connection) {
-	return func(connection *connection) {
+func WithIP(ip string) func(connection *Connection) {
+	return func(connection *Connection) {
 		connection.ip = ip
 	}
 }
-func WithPort(port int) func(connection *connection) {
-	return func(connection *connection) {
+func WithPort(port int) func(connection *Connection) {
+	return func(connection *Connection) {
 		connection.port = port
 	}
 }
 
-func New(opts ...connectionOption) *connection {
-	conn := &connection{
+func New(opts ...connectionOption) *Connection {
+	conn := &Connection{
 		ip:   "default",
 		port: 0,
 	}
@@ -31,6 +39,51 @@ func New(opts ...connectionOption) *connection {
 	return conn
 }
 
-func (c connection) ToString() string {
+//ErrAlreadyConnected is returned by Dial if the connection is open
+var ErrAlreadyConnected = errors.New("already connected")
+
+//Dial opens the connection to Addr. Cancelling ctx aborts dialing, but not the established connection
+func (c *Connection) Dial(ctx context.Context) error {
+	if c.conn != nil {
+		return ErrAlreadyConnected
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.Addr())
+	if err != nil {
+		return fmt.Errorf("dialing %s: %w", c.Addr(), err)
+	}
+	c.conn = conn
+	return nil
+}
+
+//Close closes the connection. Closing a connection that is not open does nothing
+func (c *Connection) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+func (c *Connection) IP() string {
+	return c.ip
+}
+
+func (c *Connection) Port() int {
+	return c.port
+}
+
+//Addr returns the host:port dialed by Dial
+func (c *Connection) Addr() string {
+	return net.JoinHostPort(c.ip, strconv.Itoa(c.port))
+}
+
+//Conn returns the open connection or nil before Dial
+func (c *Connection) Conn() net.Conn {
+	return c.conn
+}
+
+func (c Connection) ToString() string {
 	return fmt.Sprintf("ip: %s, port: %d", c.ip, c.port)
 }
@@ -1,8 +1,10 @@
 package options_test
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"minimalgo/options"
+	"net"
 	"testing"
 )
 
@@ -20,3 +22,30 @@ func TestOptionsPattern(t *testing.T) {
 		assert.Equal(t, "ip: localhost, port: 90008", connection.ToString())
 	}
 }
+
+func TestConnectionDial(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	connection := options.New(options.WithIP("127.0.0.1"), options.WithPort(port))
+	assert.Equal(t, "127.0.0.1", connection.IP())
+	assert.Equal(t, port, connection.Port())
+	assert.Nil(t, connection.Conn())
+
+	assert.Nil(t, connection.Dial(context.Background()))
+	assert.Equal(t, listener.Addr().String(), connection.Conn().RemoteAddr().String())
+	assert.Equal(t, options.ErrAlreadyConnected, connection.Dial(context.Background()))
+	assert.Nil(t, connection.Close())
+	assert.Nil(t, connection.Conn())
+}
+
+func TestConnectionDialFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	connection := options.New(options.WithIP("127.0.0.1"), options.WithPort(1))
+	err := connection.Dial(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, connection.Close())
+}
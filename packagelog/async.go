@@ -0,0 +1,91 @@
+package packagelog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+//AsyncLogger queues entries on a channel and writes them from a background goroutine, which removes the latency
+//of slow writers from hot paths. Entries logged after Close are written synchronously
+type AsyncLogger struct {
+	logger Logger
+	queue  chan func()
+	done   chan struct{}
+	mu     sync.RWMutex
+	closed bool
+}
+
+//Async returns an AsyncLogger writing to logger. Logging blocks once bufferSize entries are queued
+func Async(logger Logger, bufferSize int) *AsyncLogger {
+	a := &AsyncLogger{logger: logger, queue: make(chan func(), bufferSize), done: make(chan struct{})}
+	go func() {
+		defer close(a.done)
+		for write := range a.queue {
+			write()
+		}
+	}()
+	return a
+}
+
+func (a *AsyncLogger) Printf(l string, args ...interface{}) { a.logf(LevelInfo, l, args...) }
+func (a *AsyncLogger) Debugf(l string, args ...interface{}) { a.logf(LevelDebug, l, args...) }
+func (a *AsyncLogger) Infof(l string, args ...interface{})  { a.logf(LevelInfo, l, args...) }
+func (a *AsyncLogger) Warnf(l string, args ...interface{})  { a.logf(LevelWarn, l, args...) }
+func (a *AsyncLogger) Errorf(l string, args ...interface{}) { a.logf(LevelError, l, args...) }
+
+//Fatalf writes all queued entries before calling Fatalf of the logger
+func (a *AsyncLogger) Fatalf(l string, args ...interface{}) {
+	a.Close()
+	a.logger.Fatalf(l, args...)
+}
+
+func (a *AsyncLogger) Log(level Level, msg string, kv ...interface{}) {
+	var write func()
+	if writer, ok := a.logger.(*WriterLogger); ok {
+		entry := writer.newEntry(level, msg, kv) //The caller is only known on the logging goroutine
+		write = func() { writer.write(entry) }
+	} else {
+		logger := NewLoggerKV(a.logger)
+		write = func() { logger.Log(level, msg, kv...) }
+	}
+	a.enqueue(write)
+}
+
+func (a *AsyncLogger) logf(level Level, l string, args ...interface{}) {
+	a.Log(level, fmt.Sprintf(l, args...))
+}
+
+func (a *AsyncLogger) enqueue(write func()) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		write()
+		return
+	}
+	a.queue <- write
+}
+
+//Flush waits until all entries queued before the call are written or ctx is done
+func (a *AsyncLogger) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	a.enqueue(func() { close(flushed) })
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//Close writes all queued entries and stops the background goroutine. It is safe to call Close more than once
+func (a *AsyncLogger) Close() error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+	<-a.done
+	return nil
+}
@@ -0,0 +1,52 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"sync"
+	"testing"
+	"time"
+)
+
+//blockingWriter blocks writes until release is closed
+type blockingWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func (b *blockingWriter) Write(p []byte) (int, error) {
+	<-b.release
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *blockingWriter) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAsync(t *testing.T) {
+	writer := &blockingWriter{release: make(chan struct{})}
+	logger := packagelog.Async(packagelog.NewLogfmtLogger(writer, packagelog.WithClock(fixedClock)), 10)
+
+	logger.Infof("request %d", 1) //Returns right away although the writer blocks
+	logger.Infof("request %d", 2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, logger.Flush(ctx))
+	assert.Equal(t, "", writer.String())
+
+	close(writer.release)
+	assert.Nil(t, logger.Flush(context.Background()))
+	assert.Equal(t, "time=2024-03-01T12:00:00Z level=INFO caller=packagelog/async_test.go:37 msg=\"request 1\"\n"+
+		"time=2024-03-01T12:00:00Z level=INFO caller=packagelog/async_test.go:38 msg=\"request 2\"\n", writer.String())
+
+	assert.Nil(t, logger.Close())
+	logger.Warnf("written synchronously after close")
+	assert.Contains(t, writer.String(), "written synchronously after close")
+}
@@ -0,0 +1,47 @@
+package packagelog
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+//AuditFields are mandatory for every audit event
+var AuditFields = []string{"actor", "action", "resource"}
+
+//ErrMissingAuditField is returned by Audit if one of AuditFields is missing
+var ErrMissingAuditField = errors.New("missing audit field")
+
+var (
+	auditMu     sync.RWMutex
+	auditLogger Logger = NoopLogger{}
+)
+
+//SetAuditLogger sets the sink of audit events, which is separate from the module's logger, so business audit events
+//aren't mixed into diagnostics. nil discards audit events
+func SetAuditLogger(l Logger) {
+	if l == nil {
+		l = NoopLogger{}
+	}
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	auditLogger = l
+}
+
+//Audit logs event to the audit logger. Audit events ignore the module's level and hooks, but not redaction.
+//The event is dropped if one of AuditFields is missing in kv
+func Audit(event string, kv ...interface{}) error {
+	present := map[string]bool{}
+	eachField(kv, func(key string, value interface{}) {
+		present[key] = value != missingValue
+	})
+	for _, field := range AuditFields {
+		if !present[field] {
+			return fmt.Errorf("%w %q in event %s", ErrMissingAuditField, field, event)
+		}
+	}
+	auditMu.RLock()
+	defer auditMu.RUnlock()
+	NewLoggerKV(auditLogger).Log(LevelInfo, event, redact(kv)...)
+	return nil
+}
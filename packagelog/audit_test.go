@@ -0,0 +1,28 @@
+package packagelog_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+func TestAudit(t *testing.T) {
+	audit, diagnostics := packagelog.NewTestLogger(t), packagelog.NewTestLogger(t)
+	packagelog.SetAuditLogger(audit)
+	packagelog.SetLogger(diagnostics)
+	defer packagelog.SetAuditLogger(nil)
+	defer packagelog.SetLogger(nil)
+	defer packagelog.ResetLevel("")
+
+	packagelog.SetLevel("", packagelog.LevelError) //Audit events ignore the level
+	err := packagelog.Audit("user deleted", "actor", "admin", "action", "delete", "resource", "users/42")
+	assert.Nil(t, err)
+	audit.AssertLogged(t, packagelog.LevelInfo, "user deleted actor=admin action=delete resource=users/42")
+	assert.Empty(t, diagnostics.Entries())
+
+	err = packagelog.Audit("user deleted", "actor", "admin", "action", "delete")
+	assert.True(t, errors.Is(err, packagelog.ErrMissingAuditField))
+	assert.EqualError(t, err, `missing audit field "resource" in event user deleted`)
+	assert.Len(t, audit.Entries(), 1)
+}
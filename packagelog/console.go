@@ -0,0 +1,80 @@
+package packagelog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+//messageWidth aligns the fields of console lines, unless the message is longer
+const messageWidth = 40
+
+var levelColors = map[Level]string{
+	LevelDebug: "\x1b[90m",
+	LevelInfo:  "\x1b[34m",
+	LevelWarn:  "\x1b[33m",
+	LevelError: "\x1b[31m",
+}
+
+const colorReset = "\x1b[0m"
+
+//NewConsoleLogger returns a human-readable logger for development. Lines start with the time since the logger was
+//created and a colored level, fields are aligned. Colors are disabled if NO_COLOR is set
+func NewConsoleLogger(w io.Writer, opts ...LoggerOption) *WriterLogger {
+	logger := newWriterLogger(w, nil, opts)
+	start := logger.config.now()
+	_, noColor := os.LookupEnv("NO_COLOR")
+	logger.encode = func(buf *bytes.Buffer, e Entry) {
+		encodeConsole(buf, e, e.Time.Sub(start), !noColor)
+	}
+	return logger
+}
+
+func encodeConsole(buf *bytes.Buffer, e Entry, elapsed time.Duration, color bool) {
+	fmt.Fprintf(buf, "%8.3fs ", elapsed.Seconds())
+	if color {
+		buf.WriteString(levelColors[e.Level])
+	}
+	fmt.Fprintf(buf, "%-5s", e.Level)
+	if color {
+		buf.WriteString(colorReset)
+	}
+	fmt.Fprintf(buf, " %-*s", messageWidth, e.Message)
+	eachField(e.Fields, func(key string, value interface{}) {
+		buf.WriteString(" ")
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(formatValue(value))
+	})
+	if e.Caller != "" {
+		buf.WriteString(" (")
+		buf.WriteString(e.Caller)
+		buf.WriteByte(')')
+	}
+	if e.Stack != "" {
+		buf.WriteByte('\n')
+		buf.WriteString(e.Stack)
+	}
+}
+
+//Mode selects the module's logger for an environment
+type Mode int
+
+const (
+	//Development logs human-readable lines with NewConsoleLogger
+	Development Mode = iota
+	//Production logs JSON lines with NewJSONLogger
+	Production
+)
+
+//SetMode sets the module's logger to write to stderr in the format of mode, so the same call sites produce readable
+//output locally and JSON in production
+func SetMode(mode Mode) {
+	if mode == Development {
+		SetLogger(NewConsoleLogger(os.Stderr))
+		return
+	}
+	SetLogger(NewJSONLogger(os.Stderr))
+}
@@ -0,0 +1,29 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"testing"
+	"time"
+)
+
+func TestConsoleLogger(t *testing.T) {
+	now := fixedClock()
+	clock := func() time.Time {
+		now = now.Add(1500 * time.Millisecond)
+		return now
+	}
+	var buf bytes.Buffer
+	logger := packagelog.NewConsoleLogger(&buf, packagelog.WithClock(clock))
+	logger.Log(packagelog.LevelWarn, "disk almost full", "mount", "/var")
+	assert.Equal(t, "   1.500s \x1b[33mWARN \x1b[0m disk almost full                         mount=/var "+
+		"(packagelog/console_test.go:19)\n", buf.String())
+
+	buf.Reset()
+	t.Setenv("NO_COLOR", "1")
+	logger = packagelog.NewConsoleLogger(&buf, packagelog.WithClock(clock))
+	logger.Infof("connected")
+	assert.Equal(t, "   1.500s INFO  connected                                (packagelog/console_test.go:26)\n",
+		buf.String())
+}
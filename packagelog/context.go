@@ -0,0 +1,83 @@
+package packagelog
+
+import (
+	"context"
+	"fmt"
+)
+
+type contextKey int
+
+const (
+	loggerKey contextKey = iota
+	requestIDKey
+)
+
+//RequestIDKey is the field carrying the request ID of loggers returned by FromContext
+const RequestIDKey = "request_id"
+
+//WithContext returns a copy of ctx carrying l, so per-request loggers flow through the module
+func WithContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+//WithRequestID returns a copy of ctx carrying a request or trace ID, which is added to every line logged through
+//FromContext
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+//RequestID returns the ID set with WithRequestID or "" if there is none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+//FromContext returns the logger set with WithContext, or the module's logger if there is none. If ctx carries a
+//request ID, it is added to every line as request_id field
+func FromContext(ctx context.Context) LeveledLogger {
+	return fromContext(ctx)
+}
+
+func fromContext(ctx context.Context) contextLogger {
+	logger, ok := ctx.Value(loggerKey).(Logger)
+	if !ok {
+		logger = moduleLogger
+	}
+	var kv []interface{}
+	if id := RequestID(ctx); id != "" {
+		kv = append(kv, RequestIDKey, id)
+	}
+	return contextLogger{logger: logger, kv: kv}
+}
+
+//contextLogger logs through logger, adding the fields kv to every line. It applies the module's level
+type contextLogger struct {
+	logger Logger
+	kv     []interface{}
+}
+
+func (c contextLogger) Printf(l string, args ...interface{}) { c.logf(LevelInfo, l, args...) }
+func (c contextLogger) Debugf(l string, args ...interface{}) { c.logf(LevelDebug, l, args...) }
+func (c contextLogger) Infof(l string, args ...interface{})  { c.logf(LevelInfo, l, args...) }
+func (c contextLogger) Warnf(l string, args ...interface{})  { c.logf(LevelWarn, l, args...) }
+func (c contextLogger) Errorf(l string, args ...interface{}) { c.logf(LevelError, l, args...) }
+
+func (c contextLogger) Fatalf(l string, args ...interface{}) {
+	fatal(c.logger, FormatKV(fmt.Sprintf(l, args...), c.kv...))
+}
+
+func (c contextLogger) Log(level Level, msg string, kv ...interface{}) {
+	if !Enabled(level) {
+		return
+	}
+	emit(c.logger, level, msg, append(append([]interface{}{}, c.kv...), kv...))
+}
+
+func (c contextLogger) logf(level Level, l string, args ...interface{}) {
+	c.Log(level, fmt.Sprintf(l, args...))
+}
+
+//LogContext logs a structured line through the logger of ctx
+func LogContext(ctx context.Context, level Level, msg string, kv ...interface{}) {
+	fromContext(ctx).Log(level, msg, kv...)
+}
@@ -0,0 +1,25 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	var module, request bytes.Buffer
+	packagelog.SetLogger(log.New(&module, "", 0))
+	defer packagelog.SetLogger(nil)
+
+	ctx := packagelog.WithRequestID(context.Background(), "req-42")
+	packagelog.MyCoolFunction(ctx, "Paul", 43) //No logger in ctx, the module logger is used
+	assert.Equal(t, "INFO: cool function called request_id=req-42 name=Paul age=43\n", module.String())
+
+	ctx = packagelog.WithContext(ctx, log.New(&request, "", 0))
+	packagelog.FromContext(ctx).Warnf("slow query took %dms", 900)
+	assert.Equal(t, "WARN: slow query took 900ms request_id=req-42\n", request.String())
+	assert.Equal(t, "req-42", packagelog.RequestID(ctx))
+}
@@ -0,0 +1,56 @@
+package packagelog
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+//EntryCount is the number of entries the module logged at Level for Component, "" for entries of no subsystem
+type EntryCount struct {
+	Level     Level
+	Component string
+	Count     uint64
+}
+
+type countKey struct {
+	level     Level
+	component string
+}
+
+var (
+	countsMu sync.Mutex
+	counts   = map[countKey]uint64{}
+)
+
+//count increments the counter of level and the component in kv
+func count(level Level, kv []interface{}) {
+	key := countKey{level: level}
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i] == ComponentKey {
+			key.component = fmt.Sprint(kv[i+1])
+			break
+		}
+	}
+	countsMu.Lock()
+	defer countsMu.Unlock()
+	counts[key]++
+}
+
+//EntryCounts returns the number of entries logged per level and component, sorted by component and level.
+//Counting happens before sampling or deduplication by the logger, so error spikes are visible in any case
+func EntryCounts() []EntryCount {
+	countsMu.Lock()
+	result := make([]EntryCount, 0, len(counts))
+	for key, n := range counts {
+		result = append(result, EntryCount{Level: key.level, Component: key.component, Count: n})
+	}
+	countsMu.Unlock()
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Component != result[j].Component {
+			return result[i].Component < result[j].Component
+		}
+		return result[i].Level < result[j].Level
+	})
+	return result
+}
@@ -0,0 +1,29 @@
+package packagelog_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+//countOf returns the count of level and component
+func countOf(level packagelog.Level, component string) uint64 {
+	for _, count := range packagelog.EntryCounts() {
+		if count.Level == level && count.Component == component {
+			return count.Count
+		}
+	}
+	return 0
+}
+
+func TestEntryCounts(t *testing.T) {
+	packagelog.SetLogger(packagelog.NewTestLogger(t))
+	defer packagelog.SetLogger(nil)
+
+	before := countOf(packagelog.LevelError, "counts")
+	packagelog.Named("counts").Errorf("connection lost")
+	packagelog.Named("counts").Log(packagelog.LevelError, "query failed")
+	packagelog.Named("counts").Debugf("below the level, not counted")
+	assert.Equal(t, before+2, countOf(packagelog.LevelError, "counts"))
+	assert.Equal(t, uint64(0), countOf(packagelog.LevelDebug, "counts"))
+}
@@ -0,0 +1,77 @@
+package packagelog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//DedupLogger collapses identical consecutive entries, which keeps reconnect loops from spamming the same error.
+//The first entry is logged, repeats are counted and reported as "last message repeated N times" once a different
+//entry is logged or interval passed
+type DedupLogger struct {
+	logger   LoggerKV
+	fatal    Logger
+	interval time.Duration
+	mu       sync.Mutex
+	last     string
+	level    Level
+	repeated int
+	timer    *time.Timer
+}
+
+//Dedup returns a DedupLogger writing to logger, which reports repeats at least every interval
+func Dedup(logger Logger, interval time.Duration) *DedupLogger {
+	return &DedupLogger{logger: NewLoggerKV(logger), fatal: logger, interval: interval}
+}
+
+func (d *DedupLogger) Printf(l string, args ...interface{}) { d.logf(LevelInfo, l, args...) }
+func (d *DedupLogger) Debugf(l string, args ...interface{}) { d.logf(LevelDebug, l, args...) }
+func (d *DedupLogger) Infof(l string, args ...interface{})  { d.logf(LevelInfo, l, args...) }
+func (d *DedupLogger) Warnf(l string, args ...interface{})  { d.logf(LevelWarn, l, args...) }
+func (d *DedupLogger) Errorf(l string, args ...interface{}) { d.logf(LevelError, l, args...) }
+
+//Fatalf reports pending repeats before calling Fatalf of the logger
+func (d *DedupLogger) Fatalf(l string, args ...interface{}) {
+	d.Flush()
+	d.fatal.Fatalf(l, args...)
+}
+
+func (d *DedupLogger) Log(level Level, msg string, kv ...interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	line := level.String() + " " + FormatKV(msg, kv...)
+	if line == d.last {
+		d.repeated++
+		if d.timer == nil {
+			d.timer = time.AfterFunc(d.interval, d.Flush)
+		}
+		return
+	}
+	d.flush()
+	d.last, d.level = line, level
+	d.logger.Log(level, msg, kv...)
+}
+
+func (d *DedupLogger) logf(level Level, l string, args ...interface{}) {
+	d.Log(level, fmt.Sprintf(l, args...))
+}
+
+//Flush reports pending repeats of the last entry
+func (d *DedupLogger) Flush() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.flush()
+}
+
+func (d *DedupLogger) flush() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if d.repeated == 0 {
+		return
+	}
+	d.logger.Log(d.level, fmt.Sprintf("last message repeated %d times", d.repeated))
+	d.repeated = 0
+}
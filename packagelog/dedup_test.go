@@ -0,0 +1,47 @@
+package packagelog_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"testing"
+	"time"
+)
+
+func messages(logger *packagelog.TestLogger) []string {
+	var messages []string
+	for _, entry := range logger.Entries() {
+		messages = append(messages, entry.String())
+	}
+	return messages
+}
+
+func TestDedup(t *testing.T) {
+	recorder := packagelog.NewTestLogger(t)
+	logger := packagelog.Dedup(recorder, time.Hour)
+
+	for i := 0; i < 4; i++ {
+		logger.Log(packagelog.LevelError, "connection refused", "addr", "db:5432")
+	}
+	logger.Infof("connected")
+	logger.Infof("connected")
+	logger.Flush()
+	assert.Equal(t, []string{
+		"connection refused addr=db:5432",
+		"last message repeated 3 times",
+		"connected",
+		"last message repeated 1 times",
+	}, messages(recorder))
+	recorder.AssertLogged(t, packagelog.LevelError, "last message repeated 3 times")
+}
+
+func TestDedupInterval(t *testing.T) {
+	recorder := packagelog.NewTestLogger(t)
+	logger := packagelog.Dedup(recorder, 10*time.Millisecond)
+
+	logger.Errorf("connection refused")
+	logger.Errorf("connection refused")
+	assert.Eventually(t, func() bool {
+		return len(recorder.Entries()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, "last message repeated 1 times", recorder.Entries()[1].Message)
+}
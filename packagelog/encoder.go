@@ -0,0 +1,221 @@
+package packagelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+type loggerConfig struct {
+	now        func() time.Time
+	callerSkip int
+	stackLevel Level
+	stack      bool
+}
+
+type LoggerOption func(*loggerConfig)
+
+//WithClock sets the source of entry timestamps, which makes output reproducible in tests
+func WithClock(now func() time.Time) LoggerOption {
+	return func(c *loggerConfig) {
+		c.now = now
+	}
+}
+
+//WithCaller skips additional frames when determining the caller, so wrappers around the logger report their callers
+func WithCaller(skip int) LoggerOption {
+	return func(c *loggerConfig) {
+		c.callerSkip = skip
+	}
+}
+
+//WithStacktraceAt adds the stack of the caller to entries of level or above as stacktrace field
+func WithStacktraceAt(level Level) LoggerOption {
+	return func(c *loggerConfig) {
+		c.stackLevel = level
+		c.stack = true
+	}
+}
+
+//WriterLogger encodes every entry as one line to a writer. It logs all levels, the module applies its levels
+type WriterLogger struct {
+	mu     sync.Mutex
+	w      io.Writer
+	encode func(*bytes.Buffer, Entry)
+	config loggerConfig
+}
+
+//NewJSONLogger returns a logger writing entries as JSON objects with time, level, caller, msg and the fields
+func NewJSONLogger(w io.Writer, opts ...LoggerOption) *WriterLogger {
+	return newWriterLogger(w, encodeJSON, opts)
+}
+
+//NewLogfmtLogger returns a logger writing entries as logfmt lines with time, level, caller, msg and the fields
+func NewLogfmtLogger(w io.Writer, opts ...LoggerOption) *WriterLogger {
+	return newWriterLogger(w, encodeLogfmt, opts)
+}
+
+func newWriterLogger(w io.Writer, encode func(*bytes.Buffer, Entry), opts []LoggerOption) *WriterLogger {
+	config := loggerConfig{now: time.Now}
+	for idx := range opts {
+		opts[idx](&config)
+	}
+	return &WriterLogger{w: w, encode: encode, config: config}
+}
+
+func (w *WriterLogger) Printf(l string, args ...interface{}) { w.logf(LevelInfo, l, args...) }
+func (w *WriterLogger) Debugf(l string, args ...interface{}) { w.logf(LevelDebug, l, args...) }
+func (w *WriterLogger) Infof(l string, args ...interface{})  { w.logf(LevelInfo, l, args...) }
+func (w *WriterLogger) Warnf(l string, args ...interface{})  { w.logf(LevelWarn, l, args...) }
+func (w *WriterLogger) Errorf(l string, args ...interface{}) { w.logf(LevelError, l, args...) }
+
+//Fatalf logs at error level and exits like log.Fatalf, unless SetFatalBehavior says otherwise
+func (w *WriterLogger) Fatalf(l string, args ...interface{}) {
+	w.logf(LevelError, l, args...)
+	exitAfterFatal(fmt.Sprintf(l, args...))
+}
+
+func (w *WriterLogger) Log(level Level, msg string, kv ...interface{}) {
+	w.write(w.newEntry(level, msg, kv))
+}
+
+//newEntry captures time, caller and stack of an entry, which has to happen on the logging goroutine
+func (w *WriterLogger) newEntry(level Level, msg string, kv []interface{}) Entry {
+	frames := callerFrames(w.config.callerSkip)
+	entry := Entry{Time: w.config.now(), Level: level, Message: msg, Fields: kv}
+	if len(frames) > 0 {
+		entry.Caller = shortCaller(frames[0])
+	}
+	if w.config.stack && level >= w.config.stackLevel {
+		entry.Stack = stack(frames)
+	}
+	return entry
+}
+
+func (w *WriterLogger) write(entry Entry) {
+	var buf bytes.Buffer
+	w.encode(&buf, entry)
+	buf.WriteByte('\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.w.Write(buf.Bytes())
+}
+
+func (w *WriterLogger) logf(level Level, l string, args ...interface{}) {
+	w.Log(level, fmt.Sprintf(l, args...))
+}
+
+func encodeJSON(buf *bytes.Buffer, e Entry) {
+	buf.WriteString(`{"time":`)
+	buf.Write(jsonValue(e.Time.Format(time.RFC3339Nano)))
+	buf.WriteString(`,"level":`)
+	buf.Write(jsonValue(e.Level.String()))
+	if e.Caller != "" {
+		buf.WriteString(`,"caller":`)
+		buf.Write(jsonValue(e.Caller))
+	}
+	buf.WriteString(`,"msg":`)
+	buf.Write(jsonValue(e.Message))
+	eachField(e.Fields, func(key string, value interface{}) {
+		buf.WriteByte(',')
+		buf.Write(jsonValue(key))
+		buf.WriteByte(':')
+		buf.Write(jsonValue(value))
+	})
+	if e.Stack != "" {
+		buf.WriteString(`,"stacktrace":`)
+		buf.Write(jsonValue(e.Stack))
+	}
+	buf.WriteByte('}')
+}
+
+//jsonValue encodes v, using the message of errors and the fmt representation of values JSON can't encode
+func jsonValue(v interface{}) []byte {
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(v))
+	}
+	return data
+}
+
+func encodeLogfmt(buf *bytes.Buffer, e Entry) {
+	buf.WriteString("time=")
+	buf.WriteString(e.Time.Format(time.RFC3339Nano))
+	buf.WriteString(" level=")
+	buf.WriteString(e.Level.String())
+	if e.Caller != "" {
+		buf.WriteString(" caller=")
+		buf.WriteString(formatValue(e.Caller))
+	}
+	buf.WriteString(" msg=")
+	buf.WriteString(formatValue(e.Message))
+	eachField(e.Fields, func(key string, value interface{}) {
+		buf.WriteByte(' ')
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(formatValue(value))
+	})
+	if e.Stack != "" {
+		buf.WriteString(" stacktrace=")
+		buf.WriteString(formatValue(e.Stack))
+	}
+}
+
+//packagePrefix identifies the frames of this package, which are skipped when looking for the caller
+var packagePrefix = reflect.TypeOf(Entry{}).PkgPath() + "."
+
+//caller returns the dir/file.go:line of the first frame outside this package
+func caller() string {
+	frames := callerFrames(0)
+	if len(frames) == 0 {
+		return ""
+	}
+	return shortCaller(frames[0])
+}
+
+//callerFrames returns the stack starting skip frames after the first frame outside this package
+func callerFrames(skip int) []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	var stack []runtime.Frame
+	for {
+		frame, more := frames.Next()
+		if stack != nil || !strings.HasPrefix(frame.Function, packagePrefix) {
+			stack = append(stack, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	if skip >= len(stack) {
+		return nil
+	}
+	return stack[skip:]
+}
+
+func shortCaller(frame runtime.Frame) string {
+	return fmt.Sprintf("%s/%s:%d", filepath.Base(filepath.Dir(frame.File)), filepath.Base(frame.File), frame.Line)
+}
+
+//stack renders frames like a goroutine trace of panic
+func stack(frames []runtime.Frame) string {
+	var b strings.Builder
+	for idx, frame := range frames {
+		if idx > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+	return b.String()
+}
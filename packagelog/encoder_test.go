@@ -0,0 +1,57 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"testing"
+	"time"
+)
+
+func fixedClock() time.Time {
+	return time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	packagelog.SetLogger(packagelog.NewJSONLogger(&buf, packagelog.WithClock(fixedClock)))
+	defer packagelog.SetLogger(nil)
+
+	packagelog.MyCoolFunction(context.Background(), "Paul", 43)
+	packagelog.Log(packagelog.LevelError, "query failed", "err", errors.New("timeout"))
+	assert.Equal(t, `{"time":"2024-03-01T12:00:00Z","level":"INFO","caller":"packagelog/encoder_test.go:22",`+
+		`"msg":"cool function called","name":"Paul","age":43}`+"\n"+
+		`{"time":"2024-03-01T12:00:00Z","level":"ERROR","caller":"packagelog/encoder_test.go:23",`+
+		`"msg":"query failed","err":"timeout"}`+"\n", buf.String())
+}
+
+func TestLogfmtLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := packagelog.NewLogfmtLogger(&buf, packagelog.WithClock(fixedClock))
+	logger.Log(packagelog.LevelWarn, "disk almost full", "mount", "/var", "used", "91 %")
+	assert.Equal(t, `time=2024-03-01T12:00:00Z level=WARN caller=packagelog/encoder_test.go:33 `+
+		`msg="disk almost full" mount=/var used="91 %"`+"\n", buf.String())
+}
+
+//logWarning is a logging wrapper, which callers skip with WithCaller(1)
+func logWarning(logger packagelog.LoggerKV, msg string) {
+	logger.Log(packagelog.LevelWarn, msg)
+}
+
+func TestWithCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := packagelog.NewLogfmtLogger(&buf, packagelog.WithClock(fixedClock), packagelog.WithCaller(1))
+	logWarning(logger, "reported at the caller of logWarning")
+	assert.Contains(t, buf.String(), "caller=packagelog/encoder_test.go:46 ")
+}
+
+func TestWithStacktraceAt(t *testing.T) {
+	var buf bytes.Buffer
+	logger := packagelog.NewJSONLogger(&buf, packagelog.WithStacktraceAt(packagelog.LevelError))
+	logger.Warnf("no stack below error level")
+	assert.NotContains(t, buf.String(), "stacktrace")
+	logger.Errorf("connection lost")
+	assert.Contains(t, buf.String(), `"stacktrace":"minimalgo/packagelog_test.TestWithStacktraceAt\n\t`)
+}
@@ -0,0 +1,57 @@
+package packagelog
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+//FatalBehavior decides what Fatalf of the module does, so a library never has to hard-exit the host process
+type FatalBehavior int32
+
+const (
+	//ExitOnFatal calls Fatalf of the logger, which commonly exits the process. It is the default
+	ExitOnFatal FatalBehavior = iota
+	//PanicOnFatal logs at error level and panics, so the host can recover
+	PanicOnFatal
+	//ErrorOnFatal logs at error level and returns
+	ErrorOnFatal
+)
+
+var fatalBehavior = int32(ExitOnFatal)
+
+//SetFatalBehavior sets what Fatalf of the module does. It applies to Fatalf of the loggers created by this package as
+//well, e.g. by FromSlog, Tee or NewJSONLogger
+func SetFatalBehavior(b FatalBehavior) {
+	atomic.StoreInt32(&fatalBehavior, int32(b))
+}
+
+//Fatalf logs through the module's logger and applies the behavior set with SetFatalBehavior
+func Fatalf(l string, args ...interface{}) {
+	fatal(moduleLogger, fmt.Sprintf(l, args...))
+}
+
+func fatal(logger Logger, line string) {
+	switch FatalBehavior(atomic.LoadInt32(&fatalBehavior)) {
+	case PanicOnFatal:
+		emit(logger, LevelError, line, nil)
+		panic(line)
+	case ErrorOnFatal:
+		emit(logger, LevelError, line, nil)
+	default:
+		runHooks(LevelError, line, nil)
+		logger.Fatalf("%s", line)
+	}
+}
+
+//exitAfterFatal ends Fatalf of the loggers created by this package once they logged line at error level. They call it
+//instead of os.Exit, so they never exit the host unless the behavior is ExitOnFatal
+func exitAfterFatal(line string) {
+	switch FatalBehavior(atomic.LoadInt32(&fatalBehavior)) {
+	case PanicOnFatal:
+		panic(line)
+	case ErrorOnFatal:
+	default:
+		os.Exit(1)
+	}
+}
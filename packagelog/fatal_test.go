@@ -0,0 +1,48 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+func TestSetFatalBehavior(t *testing.T) {
+	logger := packagelog.NewTestLogger(t) //Its Fatalf would fail this test
+	packagelog.SetLogger(logger)
+	defer packagelog.SetLogger(nil)
+	defer packagelog.SetFatalBehavior(packagelog.ExitOnFatal)
+
+	packagelog.SetFatalBehavior(packagelog.ErrorOnFatal)
+	packagelog.Fatalf("config %s missing", "db.url")
+	logger.AssertLogged(t, packagelog.LevelError, "config db.url missing")
+
+	packagelog.SetFatalBehavior(packagelog.PanicOnFatal)
+	assert.PanicsWithValue(t, "cache unavailable component=channels", func() {
+		packagelog.Named("channels").Fatalf("cache unavailable")
+	})
+	assert.Len(t, logger.Entries(), 2)
+}
+
+func TestSetFatalBehaviorLoggers(t *testing.T) {
+	defer packagelog.SetFatalBehavior(packagelog.ExitOnFatal)
+
+	var buf bytes.Buffer
+	loggers := map[string]packagelog.Logger{
+		"json": packagelog.NewJSONLogger(&buf),
+		"tee":  packagelog.Tee(packagelog.NewLogfmtLogger(&buf)),
+		"slog": packagelog.FromSlog(slog.New(slog.NewTextHandler(&buf, nil))),
+	}
+	for name, logger := range loggers {
+		buf.Reset()
+		packagelog.SetFatalBehavior(packagelog.ErrorOnFatal)
+		logger.Fatalf("config %s missing", "db.url") //Returns instead of exiting the test binary
+		assert.Contains(t, buf.String(), "config db.url missing", name)
+
+		packagelog.SetFatalBehavior(packagelog.PanicOnFatal)
+		assert.PanicsWithValue(t, "cache unavailable", func() {
+			logger.Fatalf("cache unavailable")
+		}, name)
+	}
+}
@@ -0,0 +1,232 @@
+package packagelog
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+type fileSinkConfig struct {
+	maxSize    int64
+	maxBackups int
+	compress   bool
+	sighup     bool
+}
+
+type FileSinkOption func(*fileSinkConfig)
+
+//WithMaxSize rotates the file before it grows beyond bytes. The default is 100MB
+func WithMaxSize(bytes int64) FileSinkOption {
+	return func(c *fileSinkConfig) {
+		c.maxSize = bytes
+	}
+}
+
+//WithMaxBackups keeps n rotated files, named path.1 (the newest) to path.n. The default is 3
+func WithMaxBackups(n int) FileSinkOption {
+	return func(c *fileSinkConfig) {
+		c.maxBackups = n
+	}
+}
+
+//WithCompress gzips rotated files, named path.1.gz to path.n.gz
+func WithCompress() FileSinkOption {
+	return func(c *fileSinkConfig) {
+		c.compress = true
+	}
+}
+
+//WithReopenOnSIGHUP reopens the file when the process receives SIGHUP, which is what logrotate expects
+func WithReopenOnSIGHUP() FileSinkOption {
+	return func(c *fileSinkConfig) {
+		c.sighup = true
+	}
+}
+
+//RotatingFile is a file writer with size based rotation. Use it as writer of NewJSONLogger or NewLogfmtLogger
+type RotatingFile struct {
+	path   string
+	config fileSinkConfig
+	mu     sync.Mutex
+	file   *os.File //nil after a failed rotation or reopen, the next Write opens the file again
+	closed bool
+	size   int64
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+//FileSink opens or creates the file at path for appending
+func FileSink(path string, opts ...FileSinkOption) (*RotatingFile, error) {
+	config := fileSinkConfig{maxSize: 100 << 20, maxBackups: 3}
+	for idx := range opts {
+		opts[idx](&config)
+	}
+	r := &RotatingFile{path: path, config: config, stop: make(chan struct{})}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	if config.sighup {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGHUP)
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			defer signal.Stop(signals)
+			for {
+				select {
+				case <-signals:
+					r.Reopen()
+				case <-r.stop:
+					return
+				}
+			}
+		}()
+	}
+	return r, nil
+}
+
+//Write writes p to the file, rotating it first if p would exceed the maximum size
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.config.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+//Reopen closes and reopens the file, e.g. after an external tool moved it
+func (r *RotatingFile) Reopen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return os.ErrClosed
+	}
+	r.closeFile()
+	return r.open()
+}
+
+//Close closes the file and stops listening for SIGHUP
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	err := r.closeFile()
+	r.mu.Unlock()
+	close(r.stop)
+	r.wg.Wait()
+	return err
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+func (r *RotatingFile) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+//rotate shifts the backups, moves the file to the first backup and opens a new file. If rotating fails, the
+//original file is opened again, so writing recovers once the problem is fixed
+func (r *RotatingFile) rotate() error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	if err := r.shiftBackups(); err != nil {
+		r.open()
+		return fmt.Errorf("rotating %s: %w", r.path, err)
+	}
+	return r.open()
+}
+
+func (r *RotatingFile) shiftBackups() error {
+	if r.config.maxBackups == 0 {
+		//Without backups the file is truncated by removing it
+		return removeIfExists(r.path)
+	}
+	if err := removeIfExists(r.backup(r.config.maxBackups)); err != nil {
+		return err
+	}
+	for n := r.config.maxBackups - 1; n > 0; n-- {
+		if err := os.Rename(r.backup(n), r.backup(n+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := r.moveToBackup(); err != nil {
+		return err
+	}
+	return removeIfExists(r.path)
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *RotatingFile) backup(n int) string {
+	if r.config.compress {
+		return fmt.Sprintf("%s.%d.gz", r.path, n)
+	}
+	return fmt.Sprintf("%s.%d", r.path, n)
+}
+
+//moveToBackup moves the file to the first backup, compressing it if configured
+func (r *RotatingFile) moveToBackup() error {
+	if !r.config.compress {
+		return os.Rename(r.path, r.backup(1))
+	}
+	src, err := os.Open(r.path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(r.backup(1))
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return dst.Close()
+}
@@ -0,0 +1,107 @@
+package packagelog_test
+
+import (
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"minimalgo/packagelog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	return string(data)
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	sink, err := packagelog.FileSink(path, packagelog.WithMaxSize(10), packagelog.WithMaxBackups(2))
+	assert.Nil(t, err)
+	defer sink.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := sink.Write([]byte(line))
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, "fourth\n", readFile(t, path))
+	assert.Equal(t, "third\n", readFile(t, path+".1"))
+	assert.Equal(t, "second\n", readFile(t, path+".2"))
+	assert.NoFileExists(t, path+".3") //"first" was dropped
+}
+
+func TestFileSinkRotationFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	sink, err := packagelog.FileSink(path, packagelog.WithMaxSize(10), packagelog.WithMaxBackups(1))
+	assert.Nil(t, err)
+	defer sink.Close()
+
+	//A non-empty directory in place of the backup can't be removed, not even by root
+	assert.Nil(t, os.MkdirAll(filepath.Join(path+".1", "blocked"), 0755))
+	_, err = sink.Write([]byte("first\n"))
+	assert.Nil(t, err)
+	_, err = sink.Write([]byte("second\n"))
+	assert.ErrorContains(t, err, "rotating "+path)
+
+	assert.Nil(t, os.RemoveAll(path+".1"))
+	_, err = sink.Write([]byte("third\n")) //Recovers once the backup can be written
+	assert.Nil(t, err)
+	assert.Equal(t, "third\n", readFile(t, path))
+	assert.Equal(t, "first\n", readFile(t, path+".1"))
+	assert.Nil(t, sink.Close())
+}
+
+func TestFileSinkUnwritableDirectory(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root ignores directory permissions")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	sink, err := packagelog.FileSink(path, packagelog.WithMaxSize(10))
+	assert.Nil(t, err)
+	defer sink.Close()
+
+	sink.Write([]byte("first\n"))
+	assert.Nil(t, os.Chmod(dir, 0500))
+	_, err = sink.Write([]byte("second\n"))
+	assert.Error(t, err)
+	assert.Nil(t, os.Chmod(dir, 0700))
+	_, err = sink.Write([]byte("third\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, "first\n", readFile(t, path+".1"))
+	assert.Nil(t, sink.Close())
+}
+
+func TestFileSinkCompress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	sink, err := packagelog.FileSink(path, packagelog.WithMaxSize(10), packagelog.WithCompress())
+	assert.Nil(t, err)
+	defer sink.Close()
+
+	sink.Write([]byte("first\n"))
+	sink.Write([]byte("second\n"))
+	file, err := os.Open(path + ".1.gz")
+	assert.Nil(t, err)
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	assert.Nil(t, err)
+	data, err := io.ReadAll(zr)
+	assert.Nil(t, err)
+	assert.Equal(t, "first\n", string(data))
+}
+
+func TestFileSinkReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	sink, err := packagelog.FileSink(path)
+	assert.Nil(t, err)
+	defer sink.Close()
+
+	sink.Write([]byte("before\n"))
+	assert.Nil(t, os.Rename(path, path+".rotated")) //Like logrotate
+	assert.Nil(t, sink.Reopen())
+	sink.Write([]byte("after\n"))
+	assert.Equal(t, "before\n", readFile(t, path+".rotated"))
+	assert.Equal(t, "after\n", readFile(t, path))
+}
@@ -0,0 +1,84 @@
+package packagelog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	hooksMu sync.RWMutex
+	hooks   = map[int]func(Entry){}
+	hookID  int
+)
+
+//OnEntry calls hook for every entry the module logs above its level, e.g. to count errors or report them to an
+//error tracker. Hooks run synchronously before the entry is logged. The returned function removes the hook
+func OnEntry(hook func(Entry)) (remove func()) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hookID++
+	id := hookID
+	hooks[id] = hook
+	return func() {
+		hooksMu.Lock()
+		defer hooksMu.Unlock()
+		delete(hooks, id)
+	}
+}
+
+//runHooks counts the entry and passes it to all hooks
+func runHooks(level Level, msg string, kv []interface{}) {
+	count(level, kv)
+	//Hooks run after unlocking, so they may add or remove hooks themselves
+	hooksMu.RLock()
+	current := make([]func(Entry), 0, len(hooks))
+	for _, hook := range hooks {
+		current = append(current, hook)
+	}
+	hooksMu.RUnlock()
+	if len(current) == 0 {
+		return
+	}
+	entry := Entry{Time: time.Now(), Level: level, Message: msg, Fields: kv, Caller: caller()}
+	for _, hook := range current {
+		hook(entry)
+	}
+}
+
+//emit is the single path entries of the module take to logger. It redacts the fields
+func emit(logger Logger, level Level, msg string, kv []interface{}) {
+	kv = redact(kv)
+	runHooks(level, msg, kv)
+	NewLoggerKV(logger).Log(level, msg, kv...)
+}
+
+//teeLogger logs every entry to all loggers
+type teeLogger []Logger
+
+//Tee returns a logger writing every entry to all loggers, e.g. to stdout and to a file
+func Tee(loggers ...Logger) Logger {
+	return teeLogger(loggers)
+}
+
+func (t teeLogger) Printf(l string, args ...interface{}) { t.logf(LevelInfo, l, args...) }
+func (t teeLogger) Debugf(l string, args ...interface{}) { t.logf(LevelDebug, l, args...) }
+func (t teeLogger) Infof(l string, args ...interface{})  { t.logf(LevelInfo, l, args...) }
+func (t teeLogger) Warnf(l string, args ...interface{})  { t.logf(LevelWarn, l, args...) }
+func (t teeLogger) Errorf(l string, args ...interface{}) { t.logf(LevelError, l, args...) }
+
+//Fatalf logs at error level to all loggers and exits like log.Fatalf, unless SetFatalBehavior says otherwise
+func (t teeLogger) Fatalf(l string, args ...interface{}) {
+	t.logf(LevelError, l, args...)
+	exitAfterFatal(fmt.Sprintf(l, args...))
+}
+
+func (t teeLogger) Log(level Level, msg string, kv ...interface{}) {
+	for _, logger := range t {
+		NewLoggerKV(logger).Log(level, msg, kv...)
+	}
+}
+
+func (t teeLogger) logf(level Level, l string, args ...interface{}) {
+	t.Log(level, fmt.Sprintf(l, args...))
+}
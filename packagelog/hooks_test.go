@@ -0,0 +1,49 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+func TestTee(t *testing.T) {
+	var stdout, file bytes.Buffer
+	recorder := packagelog.NewTestLogger(t)
+	packagelog.SetLogger(packagelog.Tee(log.New(&stdout, "", 0), log.New(&file, "", 0), recorder))
+	defer packagelog.SetLogger(nil)
+
+	packagelog.Named("channels").Warnf("buffer full")
+	assert.Equal(t, "WARN: buffer full component=channels\n", stdout.String())
+	assert.Equal(t, stdout.String(), file.String())
+	recorder.AssertLogged(t, packagelog.LevelWarn, "component=channels")
+}
+
+func TestOnEntry(t *testing.T) {
+	errorCount := 0
+	remove := packagelog.OnEntry(func(entry packagelog.Entry) {
+		if entry.Level == packagelog.LevelError {
+			errorCount++
+		}
+	})
+	packagelog.Errorf("connection lost")
+	packagelog.Log(packagelog.LevelError, "query failed", "table", "users")
+	packagelog.Infof("connected")
+	packagelog.Debugf("dropped below the module's level, no hook is called")
+	remove()
+	packagelog.Errorf("not counted, the hook is removed")
+	assert.Equal(t, 2, errorCount)
+}
+
+func TestOnEntryRemoveItself(t *testing.T) {
+	calls := 0
+	var remove func()
+	remove = packagelog.OnEntry(func(packagelog.Entry) {
+		calls++
+		remove() //Hooks may change the hooks without deadlocking
+	})
+	packagelog.Errorf("connection lost")
+	packagelog.Errorf("not seen, the hook removed itself")
+	assert.Equal(t, 1, calls)
+}
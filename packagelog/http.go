@@ -0,0 +1,59 @@
+package packagelog
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+//Levels is the body of HTTPHandler. Level is the module's level, Components the levels of subsystem loggers
+type Levels struct {
+	Level      *Level           `json:"level,omitempty"`
+	Components map[string]Level `json:"components,omitempty"`
+}
+
+//HTTPHandler exposes the levels at runtime, so operators can turn on debug logging without redeploying.
+//GET returns the module's level and the levels of all subsystems, PUT sets the levels present in the body:
+//
+//	curl -X PUT -d '{"components":{"channels":"debug"}}' localhost:8080/loglevel
+func HTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var update Levels
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				http.Error(w, "invalid levels: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			if update.Level != nil {
+				SetLevel("", *update.Level)
+			}
+			for name, level := range update.Components {
+				SetLevel(name, level)
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(currentLevels())
+	})
+}
+
+//currentLevels returns the effective levels of the module, the registered subsystems and those with a level
+func currentLevels() Levels {
+	module := GetLevel("")
+	current := Levels{Level: &module, Components: map[string]Level{}}
+	for _, name := range Names() {
+		current.Components[name] = GetLevel(name)
+	}
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	for name, level := range levels {
+		if name != "" {
+			current.Components[name] = level
+		}
+	}
+	return current
+}
@@ -0,0 +1,34 @@
+package packagelog_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	defer packagelog.ResetLevel("")
+	defer packagelog.ResetLevel("routines")
+	packagelog.Named("routines")
+	handler := packagelog.HTTPHandler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/loglevel",
+		strings.NewReader(`{"level":"warn","components":{"routines":"debug"}}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, packagelog.LevelDebug, packagelog.GetLevel("routines"))
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	assert.Contains(t, recorder.Body.String(), `"level":"WARN"`)
+	assert.Contains(t, recorder.Body.String(), `"routines":"DEBUG"`)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"loud"}`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "invalid levels: unknown log level: \"loud\"\n", recorder.Body.String())
+	assert.Equal(t, packagelog.LevelWarn, packagelog.GetLevel(""))
+}
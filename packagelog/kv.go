@@ -0,0 +1,73 @@
+package packagelog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//LoggerKV is implemented by structured loggers. kv holds alternating keys and values
+type LoggerKV interface {
+	Log(level Level, msg string, kv ...interface{})
+}
+
+//printfKV adapts a Printf-style Logger to LoggerKV by rendering the fields as key=value pairs
+type printfKV struct {
+	logger Logger
+}
+
+//NewLoggerKV adapts a Printf-style logger to LoggerKV. Fields are appended to the message as name=Paul age=43
+func NewLoggerKV(l Logger) LoggerKV {
+	if kv, ok := l.(LoggerKV); ok {
+		return kv
+	}
+	return printfKV{logger: l}
+}
+
+func (p printfKV) Log(level Level, msg string, kv ...interface{}) {
+	leveledf(p.logger, level, "%s", FormatKV(msg, kv...))
+}
+
+//FormatKV renders msg followed by the key=value pairs in kv. Values containing spaces or quotes are quoted and
+//a key without value is rendered as key=(MISSING)
+func FormatKV(msg string, kv ...interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	eachField(kv, func(key string, value interface{}) {
+		b.WriteByte(' ')
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(formatValue(value))
+	})
+	return b.String()
+}
+
+//missingValue is logged for a key without value
+const missingValue = "(MISSING)"
+
+//eachField calls fn for the key/value pairs in kv
+func eachField(kv []interface{}, fn func(key string, value interface{})) {
+	for i := 0; i < len(kv); i += 2 {
+		if i+1 == len(kv) {
+			fn(fmt.Sprint(kv[i]), missingValue)
+			continue
+		}
+		fn(fmt.Sprint(kv[i]), kv[i+1])
+	}
+}
+
+func formatValue(v interface{}) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+//Log logs a structured line through the module's logger if level is enabled
+func Log(level Level, msg string, kv ...interface{}) {
+	if !Enabled(level) {
+		return
+	}
+	emit(moduleLogger, level, msg, kv)
+}
@@ -0,0 +1,51 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+//recordingKV is a structured logger keeping the fields it received
+type recordingKV struct {
+	packagelog.NoopLogger
+	fields []interface{}
+}
+
+func (r *recordingKV) Log(level packagelog.Level, msg string, kv ...interface{}) {
+	r.fields = append(r.fields, kv...)
+}
+
+func TestFormatKV(t *testing.T) {
+	var tests = []struct {
+		Name           string
+		Input          []interface{}
+		ExpectedOutput string
+	}{
+		{Name: "Plain values", Input: []interface{}{"name", "Paul", "age", 43}, ExpectedOutput: "called name=Paul age=43"},
+		{Name: "Quoted value", Input: []interface{}{"name", "Paul Smith"}, ExpectedOutput: `called name="Paul Smith"`},
+		{Name: "Missing value", Input: []interface{}{"name"}, ExpectedOutput: "called name=(MISSING)"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.ExpectedOutput, packagelog.FormatKV("called", test.Input...))
+		})
+	}
+}
+
+func TestLogKV(t *testing.T) {
+	defer packagelog.SetLogger(nil)
+
+	var buf bytes.Buffer
+	packagelog.SetLogger(log.New(&buf, "", 0))
+	packagelog.MyCoolFunction(context.Background(), "Paul", 43)
+	assert.Equal(t, "INFO: cool function called name=Paul age=43\n", buf.String())
+
+	structured := &recordingKV{}
+	packagelog.SetLogger(structured)
+	packagelog.MyCoolFunction(context.Background(), "Jill", 84)
+	assert.Equal(t, []interface{}{"name", "Jill", "age", 84}, structured.fields)
+}
@@ -0,0 +1,164 @@
+package packagelog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+//Level is the severity of a log line
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+//LevelEnv is the environment variable setting the module's initial level. It is read once at startup, SetLevel
+//overrides it at runtime
+const LevelEnv = "PACKAGELOG_LEVEL"
+
+var levelNames = map[Level]string{
+	LevelDebug: "DEBUG",
+	LevelInfo:  "INFO",
+	LevelWarn:  "WARN",
+	LevelError: "ERROR",
+}
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("LEVEL(%d)", int32(l))
+}
+
+//MarshalText encodes the level by its name, so levels are readable in JSON
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+//UnmarshalText decodes a level name accepted by ParseLevel
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+//ParseLevel parses a level name like "debug" or "WARN". "warning" is accepted as well
+func ParseLevel(s string) (Level, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if name == "WARNING" {
+		return LevelWarn, nil
+	}
+	for level, levelName := range levelNames {
+		if levelName == name {
+			return level, nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level: %q", s)
+}
+
+//LeveledLogger is the severity aware version of Logger. Most logging libraries satisfy it natively.
+//Loggers that only satisfy Logger get the level prepended to the line logged with Printf
+type LeveledLogger interface {
+	Logger
+	Debugf(l string, args ...interface{})
+	Infof(l string, args ...interface{})
+	Warnf(l string, args ...interface{})
+	Errorf(l string, args ...interface{})
+}
+
+func (NoopLogger) Debugf(l string, args ...interface{}) {}
+func (NoopLogger) Infof(l string, args ...interface{})  {}
+func (NoopLogger) Warnf(l string, args ...interface{})  {}
+func (NoopLogger) Errorf(l string, args ...interface{}) {}
+
+//levels holds the minimum level per component. "" is the module's level, which applies to components without level
+var (
+	levelsMu     sync.RWMutex
+	levels       = map[string]Level{"": initialLevel}
+	initialLevel = levelFromEnv()
+)
+
+//levelFromEnv parses PACKAGELOG_LEVEL, falling back to LevelInfo if it is unset or invalid
+func levelFromEnv() Level {
+	level, err := ParseLevel(os.Getenv(LevelEnv))
+	if err != nil {
+		return LevelInfo
+	}
+	return level
+}
+
+//SetLevel sets the minimum level of the named component at runtime. "" sets the module's level, replacing the one
+//read from PACKAGELOG_LEVEL at startup
+func SetLevel(name string, l Level) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+	levels[name] = l
+}
+
+//ResetLevel removes the level of the named component, which then logs at the module's level again. "" resets the
+//module's level to the one read from PACKAGELOG_LEVEL at startup
+func ResetLevel(name string) {
+	if name == "" {
+		SetLevel("", initialLevel)
+		return
+	}
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+	delete(levels, name)
+}
+
+//GetLevel returns the effective minimum level of the named component
+func GetLevel(name string) Level {
+	levelsMu.RLock()
+	level, ok := levels[name]
+	module := levels[""]
+	levelsMu.RUnlock()
+	if ok && name != "" {
+		return level
+	}
+	return module
+}
+
+//Enabled reports whether lines of level l are logged at the module's level
+func Enabled(l Level) bool {
+	return l >= GetLevel("")
+}
+
+func Debugf(l string, args ...interface{}) { logf(LevelDebug, l, args...) }
+func Infof(l string, args ...interface{})  { logf(LevelInfo, l, args...) }
+func Warnf(l string, args ...interface{})  { logf(LevelWarn, l, args...) }
+func Errorf(l string, args ...interface{}) { logf(LevelError, l, args...) }
+
+func logf(level Level, l string, args ...interface{}) {
+	if !Enabled(level) {
+		return
+	}
+	emit(moduleLogger, level, fmt.Sprintf(l, args...), nil)
+}
+
+//leveledf logs through the level's method of logger, or prepends the level if logger is not a LeveledLogger
+func leveledf(logger Logger, level Level, l string, args ...interface{}) {
+	leveled, ok := logger.(LeveledLogger)
+	if !ok {
+		logger.Printf(level.String()+": "+l, args...)
+		return
+	}
+	switch level {
+	case LevelDebug:
+		leveled.Debugf(l, args...)
+	case LevelInfo:
+		leveled.Infof(l, args...)
+	case LevelWarn:
+		leveled.Warnf(l, args...)
+	default:
+		leveled.Errorf(l, args...)
+	}
+}
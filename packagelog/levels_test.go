@@ -0,0 +1,51 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	var tests = []struct {
+		Name           string
+		Input          string
+		ExpectedOutput packagelog.Level
+	}{
+		{Name: "Lower case", Input: "debug", ExpectedOutput: packagelog.LevelDebug},
+		{Name: "Upper case", Input: "ERROR", ExpectedOutput: packagelog.LevelError},
+		{Name: "Warning alias", Input: "warning", ExpectedOutput: packagelog.LevelWarn},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			level, err := packagelog.ParseLevel(test.Input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.ExpectedOutput, level)
+		})
+	}
+	_, err := packagelog.ParseLevel("verbose")
+	assert.EqualError(t, err, `unknown log level: "verbose"`)
+}
+
+func TestLevels(t *testing.T) {
+	var buf bytes.Buffer
+	packagelog.SetLogger(log.New(&buf, "", 0)) //log.Logger only satisfies Logger, the level is prepended
+	defer packagelog.SetLogger(nil)
+	defer packagelog.ResetLevel("")
+
+	packagelog.SetLevel("", packagelog.LevelWarn)
+	packagelog.Infof("dropped")
+	packagelog.Warnf("disk at %d%%", 91)
+	assert.Equal(t, "WARN: disk at 91%\n", buf.String())
+
+	buf.Reset()
+	t.Setenv(packagelog.LevelEnv, "debug") //Only read at startup, SetLevel takes precedence at runtime
+	packagelog.Infof("dropped")
+	assert.Equal(t, "", buf.String())
+
+	packagelog.ResetLevel("")
+	packagelog.Infof("cache miss")
+	assert.Equal(t, "INFO: cache miss\n", buf.String())
+}
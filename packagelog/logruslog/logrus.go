@@ -0,0 +1,43 @@
+package logruslog
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"minimalgo/packagelog"
+)
+
+//logrusLogger adapts a logrus.Logger, which already has Printf, Fatalf and all level methods
+type logrusLogger struct {
+	*logrus.Logger
+}
+
+//FromLogrus adapts l to packagelog.Logger. Fields passed to packagelog.Log become logrus fields
+func FromLogrus(l *logrus.Logger) packagelog.Logger {
+	return logrusLogger{Logger: l}
+}
+
+func (l logrusLogger) Log(level packagelog.Level, msg string, kv ...interface{}) {
+	fields := logrus.Fields{}
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprint(kv[i])
+		if i+1 == len(kv) {
+			fields[key] = "(MISSING)"
+			continue
+		}
+		fields[key] = kv[i+1]
+	}
+	l.WithFields(fields).Log(logrusLevel(level), msg)
+}
+
+func logrusLevel(l packagelog.Level) logrus.Level {
+	switch l {
+	case packagelog.LevelDebug:
+		return logrus.DebugLevel
+	case packagelog.LevelInfo:
+		return logrus.InfoLevel
+	case packagelog.LevelWarn:
+		return logrus.WarnLevel
+	default:
+		return logrus.ErrorLevel
+	}
+}
@@ -0,0 +1,26 @@
+package logruslog_test
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"minimalgo/packagelog/logruslog"
+	"testing"
+)
+
+func TestFromLogrus(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	packagelog.SetLogger(logruslog.FromLogrus(logger))
+	defer packagelog.SetLogger(nil)
+
+	packagelog.MyCoolFunction(context.Background(), "Paul", 43)
+	entry := hook.LastEntry()
+	assert.Equal(t, "cool function called", entry.Message)
+	assert.Equal(t, logrus.Fields{"name": "Paul", "age": 43}, entry.Data)
+
+	packagelog.Errorf("connection to %s lost", "db")
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	assert.Equal(t, "connection to db lost", hook.LastEntry().Message)
+}
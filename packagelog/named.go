@@ -0,0 +1,74 @@
+package packagelog
+
+import (
+	"fmt"
+	"sync"
+)
+
+//ComponentKey is the field carrying the name of loggers returned by Named
+const ComponentKey = "component"
+
+//NamedLogger is the logger of a subsystem. It logs through the module's logger with a component field and applies
+//the level set for its name with SetLevel
+type NamedLogger struct {
+	name string
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*NamedLogger{}
+)
+
+//Named returns the logger of the named subsystem, e.g. Named("channels"). Loggers are registered on first use
+func Named(name string) *NamedLogger {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	logger, ok := registry[name]
+	if !ok {
+		logger = &NamedLogger{name: name}
+		registry[name] = logger
+	}
+	return logger
+}
+
+//Names returns the names of all registered subsystem loggers
+func Names() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
+
+//Name returns the subsystem name
+func (n *NamedLogger) Name() string {
+	return n.name
+}
+
+//Enabled reports whether lines of level l are logged by the subsystem
+func (n *NamedLogger) Enabled(l Level) bool {
+	return l >= GetLevel(n.name)
+}
+
+func (n *NamedLogger) Printf(l string, args ...interface{}) { n.logf(LevelInfo, l, args...) }
+func (n *NamedLogger) Debugf(l string, args ...interface{}) { n.logf(LevelDebug, l, args...) }
+func (n *NamedLogger) Infof(l string, args ...interface{})  { n.logf(LevelInfo, l, args...) }
+func (n *NamedLogger) Warnf(l string, args ...interface{})  { n.logf(LevelWarn, l, args...) }
+func (n *NamedLogger) Errorf(l string, args ...interface{}) { n.logf(LevelError, l, args...) }
+
+func (n *NamedLogger) Fatalf(l string, args ...interface{}) {
+	fatal(moduleLogger, FormatKV(fmt.Sprintf(l, args...), ComponentKey, n.name))
+}
+
+func (n *NamedLogger) Log(level Level, msg string, kv ...interface{}) {
+	if !n.Enabled(level) {
+		return
+	}
+	emit(moduleLogger, level, msg, append([]interface{}{ComponentKey, n.name}, kv...))
+}
+
+func (n *NamedLogger) logf(level Level, l string, args ...interface{}) {
+	n.Log(level, fmt.Sprintf(l, args...))
+}
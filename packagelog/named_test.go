@@ -0,0 +1,26 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+func TestNamed(t *testing.T) {
+	var buf bytes.Buffer
+	packagelog.SetLogger(log.New(&buf, "", 0))
+	defer packagelog.SetLogger(nil)
+	defer packagelog.ResetLevel("channels")
+
+	channels := packagelog.Named("channels")
+	assert.Same(t, channels, packagelog.Named("channels"))
+	assert.Contains(t, packagelog.Names(), "channels")
+
+	channels.Debugf("buffer size %d", 10) //Dropped at the module's level
+	packagelog.SetLevel("channels", packagelog.LevelDebug)
+	channels.Debugf("buffer size %d", 10)
+	packagelog.Named("routines").Debugf("dropped, only channels logs debug")
+	assert.Equal(t, "DEBUG: buffer size 10 component=channels\n", buf.String())
+}
@@ -1,5 +1,7 @@
 package packagelog
 
+import "context"
+
 //Logger is the module's logging interface. This obe is compatible with the standard os logger,
 //but won't be great for a lot of popular logging libraries.
 type Logger interface {
@@ -16,14 +18,25 @@ type NoopLogger struct{}
 func (NoopLogger) Printf(l string, args ...interface{}) {}
 func (NoopLogger) Fatalf(l string, args ...interface{}) {}
 
-var moduleLogger Logger = NoopLogger{}
+//moduleLogger defaults to slog, which stays silent until the application configures slog.SetDefault
+var moduleLogger Logger = slogDefault{}
 
-//SetLogger allows the package user to provide his own implementation
+//SetLogger allows the package user to provide his own implementation. nil restores the slog default
 func SetLogger(l Logger) {
+	if l == nil {
+		l = slogDefault{}
+	}
 	moduleLogger = l
 }
 
-func MyCoolFunction(name string, age int) {
-	//The module just logs through the moduleLogger
-	moduleLogger.Printf("Name: %s, Age: %d", name, age)
+//Printf logs through the module's logger. Other packages of the module use it, so all module logs go to the logger
+//set with SetLogger
+func Printf(l string, args ...interface{}) {
+	moduleLogger.Printf(l, args...)
+}
+
+func MyCoolFunction(ctx context.Context, name string, age int) {
+	//The module just logs through the logger of ctx, which defaults to the moduleLogger. Structured loggers receive
+	//name and age as fields
+	LogContext(ctx, LevelInfo, "cool function called", "name", name, "age", age)
 }
@@ -1,13 +1,14 @@
 package packagelog_test
 
 import (
+	"context"
 	"log"
 	"minimalgo/packagelog"
 	"testing"
 )
 
 func TestMyCoolFunction(t *testing.T) {
-	packagelog.MyCoolFunction("Paul", 43) //Logs nothing
+	packagelog.MyCoolFunction(context.Background(), "Paul", 43) //Logs nothing
 	packagelog.SetLogger(log.Default())
-	packagelog.MyCoolFunction("Jill", 84) //Logs using standard library logger
+	packagelog.MyCoolFunction(context.Background(), "Jill", 84) //Logs using standard library logger
 }
@@ -0,0 +1,30 @@
+package promlog
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"minimalgo/packagelog"
+)
+
+var entriesDesc = prometheus.NewDesc("packagelog_entries_total", "Number of entries logged by the module.",
+	[]string{"level", "component"}, nil)
+
+//entryCollector exposes packagelog.EntryCounts as counters
+type entryCollector struct{}
+
+//Collector returns a prometheus.Collector exposing packagelog_entries_total{level,component}:
+//
+//	prometheus.MustRegister(promlog.Collector())
+func Collector() prometheus.Collector {
+	return entryCollector{}
+}
+
+func (entryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- entriesDesc
+}
+
+func (entryCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, count := range packagelog.EntryCounts() {
+		ch <- prometheus.MustNewConstMetric(entriesDesc, prometheus.CounterValue, float64(count.Count),
+			count.Level.String(), count.Component)
+	}
+}
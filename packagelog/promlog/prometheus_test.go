@@ -0,0 +1,25 @@
+package promlog_test
+
+import (
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"minimalgo/packagelog/promlog"
+	"strings"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	packagelog.SetLogger(packagelog.NewTestLogger(t))
+	defer packagelog.SetLogger(nil)
+
+	packagelog.Named("prometheus").Errorf("connection lost")
+	packagelog.Named("prometheus").Errorf("connection lost")
+
+	err := testutil.CollectAndCompare(promlog.Collector(), strings.NewReader(`
+# HELP packagelog_entries_total Number of entries logged by the module.
+# TYPE packagelog_entries_total counter
+packagelog_entries_total{component="prometheus",level="ERROR"} 2
+`), "packagelog_entries_total")
+	assert.Nil(t, err)
+}
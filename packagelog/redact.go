@@ -0,0 +1,66 @@
+package packagelog
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+//redacted replaces the values of secrets
+const redacted = "***"
+
+//Secret is a value that is never logged. It renders as *** in text, JSON and slog output
+type Secret string
+
+func (Secret) String() string {
+	return redacted
+}
+
+//GoString prevents %#v from printing the secret
+func (Secret) GoString() string {
+	return redacted
+}
+
+func (Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+func (Secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+var (
+	redactMu   sync.RWMutex
+	redactKeys = map[string]bool{}
+)
+
+//RedactKeys makes the module log *** instead of the values of fields with one of keys, ignoring case
+func RedactKeys(keys ...string) {
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	for _, key := range keys {
+		redactKeys[strings.ToLower(key)] = true
+	}
+}
+
+//redact returns kv with the values of redacted keys replaced. kv is copied if a value is replaced
+func redact(kv []interface{}) []interface{} {
+	redactMu.RLock()
+	defer redactMu.RUnlock()
+	if len(redactKeys) == 0 {
+		return kv
+	}
+	copied := false
+	for i := 0; i+1 < len(kv); i += 2 {
+		if !redactKeys[strings.ToLower(fmt.Sprint(kv[i]))] {
+			continue
+		}
+		if !copied {
+			kv = append([]interface{}(nil), kv...)
+			copied = true
+		}
+		kv[i+1] = redacted
+	}
+	return kv
+}
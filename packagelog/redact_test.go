@@ -0,0 +1,30 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+func TestRedactKeys(t *testing.T) {
+	logger := packagelog.NewTestLogger(t)
+	packagelog.SetLogger(logger)
+	defer packagelog.SetLogger(nil)
+
+	packagelog.RedactKeys("password")
+	packagelog.Log(packagelog.LevelInfo, "login", "user", "paul", "Password", "hunter2")
+	logger.AssertLogged(t, packagelog.LevelInfo, "login user=paul Password=***")
+}
+
+func TestSecret(t *testing.T) {
+	token := packagelog.Secret("eyJhbGciOi")
+	assert.Equal(t, "***", fmt.Sprint(token))
+	assert.Equal(t, "***", fmt.Sprintf("%#v", token))
+
+	var buf bytes.Buffer
+	packagelog.NewJSONLogger(&buf).Log(packagelog.LevelInfo, "token refreshed", "token", token)
+	assert.Contains(t, buf.String(), `"token":"***"`)
+	assert.Equal(t, "token refreshed token=***", packagelog.FormatKV("token refreshed", "token", token))
+}
@@ -0,0 +1,82 @@
+package packagelog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+//slogLogger adapts a *slog.Logger to LeveledLogger and LoggerKV
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+//FromSlog adapts l to the module's logging interfaces. Fields passed to Log become slog attributes
+func FromSlog(l *slog.Logger) Logger {
+	return slogLogger{logger: l}
+}
+
+func (s slogLogger) Printf(l string, args ...interface{}) { s.logf(LevelInfo, l, args...) }
+func (s slogLogger) Debugf(l string, args ...interface{}) { s.logf(LevelDebug, l, args...) }
+func (s slogLogger) Infof(l string, args ...interface{})  { s.logf(LevelInfo, l, args...) }
+func (s slogLogger) Warnf(l string, args ...interface{})  { s.logf(LevelWarn, l, args...) }
+func (s slogLogger) Errorf(l string, args ...interface{}) { s.logf(LevelError, l, args...) }
+
+func (s slogLogger) Fatalf(l string, args ...interface{}) {
+	s.logf(LevelError, l, args...)
+	exitAfterFatal(fmt.Sprintf(l, args...))
+}
+
+func (s slogLogger) Log(level Level, msg string, kv ...interface{}) {
+	s.logger.Log(context.Background(), slogLevel(level), msg, kv...)
+}
+
+func (s slogLogger) logf(level Level, l string, args ...interface{}) {
+	s.logger.Log(context.Background(), slogLevel(level), fmt.Sprintf(l, args...))
+}
+
+func slogLevel(l Level) slog.Level {
+	switch l {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+//builtinHandler is the handler slog uses until the application calls slog.SetDefault, compared by identity. slog is
+//initialized before this package, so it is captured before main can replace it. Only an init function of a package
+//initialized earlier, which can't depend on this package, could call slog.SetDefault first
+var builtinHandler = slog.Default().Handler()
+
+//isBuiltinHandler reports whether h is slog's builtin handler, which writes through the log package
+func isBuiltinHandler(h slog.Handler) bool {
+	return h == builtinHandler
+}
+
+//slogDefault is the module's default backend. It logs through slog.Default() once the application configured
+//slog with its own handler and stays silent otherwise, like NoopLogger
+type slogDefault struct{}
+
+func (slogDefault) backend() LeveledLogger {
+	logger := slog.Default()
+	if isBuiltinHandler(logger.Handler()) {
+		return NoopLogger{}
+	}
+	return slogLogger{logger: logger}
+}
+
+func (d slogDefault) Printf(l string, args ...interface{}) { d.backend().Printf(l, args...) }
+func (d slogDefault) Fatalf(l string, args ...interface{}) { d.backend().Fatalf(l, args...) }
+func (d slogDefault) Debugf(l string, args ...interface{}) { d.backend().Debugf(l, args...) }
+func (d slogDefault) Infof(l string, args ...interface{})  { d.backend().Infof(l, args...) }
+func (d slogDefault) Warnf(l string, args ...interface{})  { d.backend().Warnf(l, args...) }
+func (d slogDefault) Errorf(l string, args ...interface{}) { d.backend().Errorf(l, args...) }
+
+func (d slogDefault) Log(level Level, msg string, kv ...interface{}) {
+	NewLoggerKV(d.backend()).Log(level, msg, kv...)
+}
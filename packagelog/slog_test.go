@@ -0,0 +1,51 @@
+package packagelog_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"log/slog"
+	"minimalgo/packagelog"
+	"os"
+	"testing"
+)
+
+//newTextLogger returns a slog logger writing to buf without timestamps
+func newTextLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestFromSlog(t *testing.T) {
+	defer packagelog.SetLogger(nil)
+
+	var buf bytes.Buffer
+	packagelog.SetLogger(packagelog.FromSlog(newTextLogger(&buf)))
+	packagelog.MyCoolFunction(context.Background(), "Paul", 43)
+	packagelog.Warnf("disk at %d%%", 91)
+	assert.Equal(t, "level=INFO msg=\"cool function called\" name=Paul age=43\n"+
+		"level=WARN msg=\"disk at 91%\"\n", buf.String())
+}
+
+func TestSlogDefault(t *testing.T) {
+	builtin := slog.Default()
+	defer slog.SetDefault(builtin)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf) //The builtin handler writes through the log package
+	defer log.SetOutput(os.Stderr)
+	//Replacing the default logger with one using the builtin handler doesn't configure slog
+	slog.SetDefault(slog.New(builtin.Handler()))
+	packagelog.MyCoolFunction(context.Background(), "Paul", 43) //Logs nothing, slog is not configured
+	assert.Empty(t, buf.String())
+	slog.SetDefault(newTextLogger(&buf))
+	packagelog.MyCoolFunction(context.Background(), "Jill", 84) //Logs through slog without calling SetLogger
+	assert.Equal(t, "level=INFO msg=\"cool function called\" name=Jill age=84\n", buf.String())
+}
@@ -0,0 +1,84 @@
+package packagelog
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//Entry is a single log line
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  []interface{}
+	//Caller is the dir/file.go:line logging the entry, if known
+	Caller string
+	//Stack is the stack of the caller, if the logger was created WithStacktraceAt the entry's level
+	Stack string
+}
+
+//String renders the entry like FormatKV, e.g. "cool function called name=Paul age=43"
+func (e Entry) String() string {
+	return FormatKV(e.Message, e.Fields...)
+}
+
+//TestLogger records entries in memory so tests can assert what was logged. Fatalf fails the test instead of exiting
+type TestLogger struct {
+	t       testing.TB
+	mu      sync.Mutex
+	entries []Entry
+}
+
+//NewTestLogger returns a TestLogger failing t on Fatalf
+func NewTestLogger(t testing.TB) *TestLogger {
+	return &TestLogger{t: t}
+}
+
+func (tl *TestLogger) Printf(l string, args ...interface{}) { tl.logf(LevelInfo, l, args...) }
+func (tl *TestLogger) Debugf(l string, args ...interface{}) { tl.logf(LevelDebug, l, args...) }
+func (tl *TestLogger) Infof(l string, args ...interface{})  { tl.logf(LevelInfo, l, args...) }
+func (tl *TestLogger) Warnf(l string, args ...interface{})  { tl.logf(LevelWarn, l, args...) }
+func (tl *TestLogger) Errorf(l string, args ...interface{}) { tl.logf(LevelError, l, args...) }
+
+func (tl *TestLogger) Fatalf(l string, args ...interface{}) {
+	tl.Log(LevelError, fmt.Sprintf(l, args...))
+	tl.t.Helper()
+	tl.t.Fatalf("fatal log: "+l, args...)
+}
+
+func (tl *TestLogger) Log(level Level, msg string, kv ...interface{}) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	tl.entries = append(tl.entries, Entry{Time: time.Now(), Level: level, Message: msg, Fields: kv})
+}
+
+func (tl *TestLogger) logf(level Level, l string, args ...interface{}) {
+	tl.Log(level, fmt.Sprintf(l, args...))
+}
+
+//Entries returns all recorded entries in order
+func (tl *TestLogger) Entries() []Entry {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	return append([]Entry(nil), tl.entries...)
+}
+
+//AssertLogged fails t unless an entry of level contains substring in its message or fields
+func (tl *TestLogger) AssertLogged(t testing.TB, level Level, substring string) bool {
+	t.Helper()
+	entries := tl.Entries()
+	for _, entry := range entries {
+		if entry.Level == level && strings.Contains(entry.String(), substring) {
+			return true
+		}
+	}
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = fmt.Sprintf("\t%s: %s", entry.Level, entry)
+	}
+	t.Errorf("no %s entry contains %q, logged:\n%s", level, substring, strings.Join(lines, "\n"))
+	return false
+}
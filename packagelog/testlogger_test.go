@@ -0,0 +1,27 @@
+package packagelog_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"minimalgo/packagelog"
+	"testing"
+)
+
+func TestTestLogger(t *testing.T) {
+	logger := packagelog.NewTestLogger(t)
+	packagelog.SetLogger(logger)
+	defer packagelog.SetLogger(nil)
+
+	packagelog.MyCoolFunction(context.Background(), "Paul", 43)
+	packagelog.Warnf("disk at %d%%", 91)
+
+	logger.AssertLogged(t, packagelog.LevelInfo, "name=Paul")
+	logger.AssertLogged(t, packagelog.LevelWarn, "disk at 91%")
+	entries := logger.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, []interface{}{"name", "Paul", "age", 43}, entries[0].Fields)
+
+	failing := &testing.T{}
+	assert.False(t, logger.AssertLogged(failing, packagelog.LevelError, "disk"))
+	assert.True(t, failing.Failed())
+}
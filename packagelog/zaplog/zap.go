@@ -0,0 +1,33 @@
+package zaplog
+
+import (
+	"go.uber.org/zap"
+	"minimalgo/packagelog"
+)
+
+//zapLogger adapts a zap.SugaredLogger. Sugared loggers have all level methods except Printf
+type zapLogger struct {
+	*zap.SugaredLogger
+}
+
+//FromZap adapts l to packagelog.Logger. Fields passed to packagelog.Log become zap fields
+func FromZap(l *zap.SugaredLogger) packagelog.Logger {
+	return zapLogger{SugaredLogger: l}
+}
+
+func (z zapLogger) Printf(l string, args ...interface{}) {
+	z.Infof(l, args...)
+}
+
+func (z zapLogger) Log(level packagelog.Level, msg string, kv ...interface{}) {
+	switch level {
+	case packagelog.LevelDebug:
+		z.Debugw(msg, kv...)
+	case packagelog.LevelInfo:
+		z.Infow(msg, kv...)
+	case packagelog.LevelWarn:
+		z.Warnw(msg, kv...)
+	default:
+		z.Errorw(msg, kv...)
+	}
+}
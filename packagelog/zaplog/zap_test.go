@@ -0,0 +1,28 @@
+package zaplog_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"minimalgo/packagelog"
+	"minimalgo/packagelog/zaplog"
+	"testing"
+)
+
+func TestFromZap(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	packagelog.SetLogger(zaplog.FromZap(zap.New(core).Sugar()))
+	defer packagelog.SetLogger(nil)
+
+	packagelog.MyCoolFunction(context.Background(), "Paul", 43)
+	packagelog.Warnf("disk at %d%%", 91)
+
+	entries := logs.All()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "cool function called", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"name": "Paul", "age": int64(43)}, entries[0].ContextMap())
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, "disk at 91%", entries[1].Message)
+}